@@ -0,0 +1,113 @@
+// Command controller runs the PodGroup and ElasticQuota controllers,
+// reconciling those custom resources against their member pods.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/klog/v2"
+
+	schedulingv1alpha1 "k8s-scheduler/pkg/apis/scheduling/v1alpha1"
+	clientset "k8s-scheduler/pkg/client/clientset/versioned"
+	"k8s-scheduler/pkg/controller/elasticquota"
+	"k8s-scheduler/pkg/controller/podgroup"
+)
+
+var (
+	kubeconfig = flag.String("kubeconfig", "", "path to a kubeconfig; uses in-cluster config when empty")
+	workers    = flag.Int("workers", 2, "number of workers to run for each of the PodGroup and ElasticQuota controllers")
+	resync     = flag.Duration("resync", 30*time.Second, "informer resync period")
+)
+
+func main() {
+	flag.Parse()
+
+	cfg, err := clientcmd.BuildConfigFromFlags("", *kubeconfig)
+	if err != nil {
+		klog.ErrorS(err, "failed to build kubeconfig")
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(1)
+	}
+
+	kubeClient, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		klog.ErrorS(err, "failed to build kube client")
+		os.Exit(1)
+	}
+
+	pgClient, err := clientset.NewForConfig(cfg)
+	if err != nil {
+		klog.ErrorS(err, "failed to build PodGroup client")
+		os.Exit(1)
+	}
+
+	sharedInformers := informers.NewSharedInformerFactory(kubeClient, *resync)
+	podInformer := sharedInformers.Core().V1().Pods().Informer()
+
+	podGroupInformer := newPodGroupInformer(pgClient, *resync)
+	elasticQuotaInformer := newElasticQuotaInformer(pgClient, *resync)
+
+	podGroupCtrl := podgroup.NewController(kubeClient, pgClient, podInformer, podGroupInformer)
+	elasticQuotaCtrl := elasticquota.NewController(kubeClient, pgClient, podInformer, elasticQuotaInformer)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sharedInformers.Start(ctx.Done())
+	go podGroupInformer.Run(ctx.Done())
+	go elasticQuotaInformer.Run(ctx.Done())
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	var podGroupErr, elasticQuotaErr error
+	go func() {
+		defer wg.Done()
+		podGroupErr = podGroupCtrl.Run(ctx, *workers)
+	}()
+	go func() {
+		defer wg.Done()
+		elasticQuotaErr = elasticQuotaCtrl.Run(ctx, *workers)
+	}()
+	wg.Wait()
+
+	if podGroupErr != nil {
+		klog.ErrorS(podGroupErr, "PodGroup controller exited with error")
+		os.Exit(1)
+	}
+	if elasticQuotaErr != nil {
+		klog.ErrorS(elasticQuotaErr, "ElasticQuota controller exited with error")
+		os.Exit(1)
+	}
+}
+
+// newPodGroupInformer builds a SharedIndexInformer over PodGroup objects
+// across all namespaces using the generated clientset's list/watch calls.
+func newPodGroupInformer(pgClient clientset.Interface, resync time.Duration) cache.SharedIndexInformer {
+	return cache.NewSharedIndexInformer(
+		podgroup.NewPodGroupListWatch(pgClient),
+		&schedulingv1alpha1.PodGroup{},
+		resync,
+		cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc},
+	)
+}
+
+// newElasticQuotaInformer builds a SharedIndexInformer over ElasticQuota
+// objects across all namespaces using the generated clientset's list/watch
+// calls.
+func newElasticQuotaInformer(eqClient clientset.Interface, resync time.Duration) cache.SharedIndexInformer {
+	return cache.NewSharedIndexInformer(
+		elasticquota.NewElasticQuotaListWatch(eqClient),
+		&schedulingv1alpha1.ElasticQuota{},
+		resync,
+		cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc},
+	)
+}
@@ -4,17 +4,41 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/spf13/cobra"
 	"k8s.io/klog/v2"
 	schedulerapp "k8s.io/kubernetes/cmd/kube-scheduler/app"
 
+	"k8s-scheduler/pkg/metrics"
+	"k8s-scheduler/pkg/plugins/batchenqueue"
 	"k8s-scheduler/pkg/plugins/batchpermit"
+	"k8s-scheduler/pkg/plugins/elasticquota"
 )
 
 func main() {
 	cmd := schedulerapp.NewSchedulerCommand(
+		schedulerapp.WithPlugin(batchenqueue.Name, batchenqueue.BuildConfig()),
 		schedulerapp.WithPlugin(batchpermit.Name, batchpermit.BuildConfig()),
+		schedulerapp.WithPlugin(elasticquota.Name, elasticquota.BuildConfig()),
 	)
 
+	var listenAddress string
+	cmd.Flags().StringVar(&listenAddress, "listen-address", ":8081", "address to serve Prometheus metrics and batch scheduling introspection endpoints on")
+
+	previousPreRunE := cmd.PreRunE
+	cmd.PreRunE = func(c *cobra.Command, args []string) error {
+		if previousPreRunE != nil {
+			if err := previousPreRunE(c, args); err != nil {
+				return err
+			}
+		}
+		go func() {
+			if err := metrics.Serve(listenAddress, batchpermit.DebugGroupsHandler()); err != nil {
+				klog.ErrorS(err, "metrics server exited with error")
+			}
+		}()
+		return nil
+	}
+
 	if err := cmd.Execute(); err != nil {
 		klog.ErrorS(err, "scheduler exited with error")
 		fmt.Fprintf(os.Stderr, "%v\n", err)
@@ -0,0 +1,192 @@
+package v1alpha1
+
+import (
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PodGroupPhase represents the lifecycle phase of a PodGroup.
+type PodGroupPhase string
+
+const (
+	// PodGroupPending means the PodGroup has been accepted by the system but
+	// not all members have been observed yet.
+	PodGroupPending PodGroupPhase = "Pending"
+	// PodGroupInqueue means the PodGroup has passed the resource admission
+	// gate and its members are eligible to be released together.
+	PodGroupInqueue PodGroupPhase = "Inqueue"
+	// PodGroupRunning means at least one member of the PodGroup is running.
+	PodGroupRunning PodGroupPhase = "Running"
+	// PodGroupUnknown means the controller found no member pods for the
+	// PodGroup, whether none has been created yet or all have been deleted.
+	PodGroupUnknown PodGroupPhase = "Unknown"
+	// PodGroupFailed means the PodGroup's gang lifecycle could not complete,
+	// for example because a lifecycle hook did not become ready before its
+	// timeout. Failed is not terminal: the rejected members return to the
+	// scheduling queue, and a later admission moves the PodGroup back to
+	// Inqueue.
+	PodGroupFailed PodGroupPhase = "Failed"
+)
+
+// PodGroupHookEvent identifies a gang lifecycle boundary at which hooks run.
+type PodGroupHookEvent string
+
+const (
+	// HookPreAdmit hooks run before the gang's waiting pods are allowed to
+	// proceed to binding.
+	HookPreAdmit PodGroupHookEvent = "pre-admit"
+	// HookPostAdmit hooks run once every member of the gang has been bound.
+	HookPostAdmit PodGroupHookEvent = "post-admit"
+	// HookPreEvict hooks run before a rejected gang's waiting state is wiped.
+	HookPreEvict PodGroupHookEvent = "pre-evict"
+	// HookPostEvict hooks run after a rejected gang's waiting state has been
+	// wiped.
+	HookPostEvict PodGroupHookEvent = "post-evict"
+)
+
+// HookSpec describes a single lifecycle hook Job. Hooks declared for the
+// same event run in ascending Weight order. A hook with no explicit
+// TimeoutSeconds uses its event's default (60s for pre-admit/pre-evict,
+// 600s for post-admit/post-evict).
+type HookSpec struct {
+	// Name identifies the hook within its event, for logging and the Job's
+	// generated name.
+	Name string `json:"name"`
+
+	// Template is the pod template run as the hook's Job.
+	Template v1.PodTemplateSpec `json:"template"`
+
+	// Weight orders hooks within the same event; lower runs first.
+	// +optional
+	Weight int32 `json:"weight,omitempty"`
+
+	// TimeoutSeconds bounds how long the gang waits for this hook to become
+	// ready before the event fails. Defaults to the event's default timeout
+	// when zero.
+	// +optional
+	TimeoutSeconds int32 `json:"timeoutSeconds,omitempty"`
+}
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:path=podgroups,scope=Namespaced,shortName=pg
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
+// +kubebuilder:printcolumn:name="MinMember",type=integer,JSONPath=`.spec.minMember`
+// +kubebuilder:printcolumn:name="Scheduled",type=integer,JSONPath=`.status.scheduled`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// PodGroup defines a group of pods that must be scheduled as a gang.
+type PodGroup struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   PodGroupSpec   `json:"spec,omitempty"`
+	Status PodGroupStatus `json:"status,omitempty"`
+}
+
+// PodGroupSpec describes the desired state of a PodGroup.
+type PodGroupSpec struct {
+	// MinMember is the minimum number of pods that must be scheduled together
+	// for the group to be considered admitted.
+	MinMember int32 `json:"minMember"`
+
+	// MinResources is the sum of resources required by MinMember pods. The
+	// group is not admitted until the cluster can provide at least this much.
+	// +optional
+	MinResources v1.ResourceList `json:"minResources,omitempty"`
+
+	// PriorityClassName is the priority class shared by members of the group.
+	// +optional
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+
+	// Hooks declares the lifecycle hooks to run at each gang boundary. It can
+	// also be declared per-event via the "batch.scheduling.k8s.io/hook-<event>"
+	// annotation, as a JSON-encoded list of HookSpec, for callers that would
+	// rather not extend the PodGroup spec.
+	// +optional
+	Hooks map[PodGroupHookEvent][]HookSpec `json:"hooks,omitempty"`
+}
+
+// PodGroupStatus describes the observed state of a PodGroup.
+type PodGroupStatus struct {
+	// Phase is the current lifecycle phase of the PodGroup.
+	Phase PodGroupPhase `json:"phase,omitempty"`
+
+	// Scheduled is the number of members that have been bound to a node.
+	Scheduled int32 `json:"scheduled,omitempty"`
+	// Running is the number of members currently running.
+	Running int32 `json:"running,omitempty"`
+	// Succeeded is the number of members that have completed successfully.
+	Succeeded int32 `json:"succeeded,omitempty"`
+	// Failed is the number of members that have failed.
+	Failed int32 `json:"failed,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// PodGroupList is a collection of PodGroup objects.
+type PodGroupList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []PodGroup `json:"items"`
+}
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:path=elasticquotas,scope=Namespaced,shortName=eq
+
+// ElasticQuota defines a guaranteed Min and a burstable Max of resources
+// shared by a tenant's namespaces, so batch workloads can coexist with
+// guaranteed and burstable capacity on the same cluster.
+type ElasticQuota struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ElasticQuotaSpec   `json:"spec,omitempty"`
+	Status ElasticQuotaStatus `json:"status,omitempty"`
+}
+
+// ElasticQuotaSpec describes the desired state of an ElasticQuota.
+type ElasticQuotaSpec struct {
+	// Min is the resources guaranteed to the quota's namespaces. A gang in a
+	// namespace using less than Min is given priority over gangs in
+	// namespaces already using at least their Min.
+	// +optional
+	Min v1.ResourceList `json:"min,omitempty"`
+
+	// Max is the resources the quota's namespaces may never exceed in total.
+	// BatchPermit does not release a gang whose MinResources would push its
+	// namespace's usage over Max; a gang that declares no MinResources is not
+	// checked against Max at release.
+	// +optional
+	Max v1.ResourceList `json:"max,omitempty"`
+
+	// Namespaces selects additional namespaces, beyond the ElasticQuota's
+	// own, whose usage counts against this quota. Leave nil to scope the
+	// quota to its own namespace only.
+	// +optional
+	Namespaces *metav1.LabelSelector `json:"namespaces,omitempty"`
+}
+
+// ElasticQuotaStatus describes the observed state of an ElasticQuota.
+type ElasticQuotaStatus struct {
+	// Used is the sum of resource requests of non-terminal pods across the
+	// quota's member namespaces, as last observed by the ElasticQuota controller.
+	// +optional
+	Used v1.ResourceList `json:"used,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ElasticQuotaList is a collection of ElasticQuota objects.
+type ElasticQuotaList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []ElasticQuota `json:"items"`
+}
@@ -0,0 +1,36 @@
+// Package versioned provides the generated clientset for the
+// scheduling.k8s-scheduler.io API group.
+package versioned
+
+import (
+	"k8s.io/client-go/rest"
+
+	schedulingv1alpha1 "k8s-scheduler/pkg/client/clientset/versioned/typed/scheduling/v1alpha1"
+)
+
+// Interface describes the clientset exposed by this package.
+type Interface interface {
+	SchedulingV1alpha1() schedulingv1alpha1.SchedulingV1alpha1Interface
+}
+
+// Clientset is the default implementation of Interface.
+type Clientset struct {
+	schedulingV1alpha1 *schedulingv1alpha1.SchedulingV1alpha1Client
+}
+
+// SchedulingV1alpha1 returns the client for the scheduling.k8s-scheduler.io/v1alpha1 API group.
+func (c *Clientset) SchedulingV1alpha1() schedulingv1alpha1.SchedulingV1alpha1Interface {
+	return c.schedulingV1alpha1
+}
+
+// NewForConfig creates a new Clientset for the given config.
+func NewForConfig(c *rest.Config) (*Clientset, error) {
+	configShallowCopy := *c
+	var cs Clientset
+	var err error
+	cs.schedulingV1alpha1, err = schedulingv1alpha1.NewForConfig(&configShallowCopy)
+	if err != nil {
+		return nil, err
+	}
+	return &cs, nil
+}
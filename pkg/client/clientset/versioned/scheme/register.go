@@ -0,0 +1,27 @@
+// Package scheme holds the scheme used by the generated clientset, with the
+// scheduling.k8s-scheduler.io types registered alongside the built-in types.
+package scheme
+
+import (
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+
+	schedulingv1alpha1 "k8s-scheduler/pkg/apis/scheduling/v1alpha1"
+)
+
+var (
+	// Scheme is the default instance of runtime.Scheme to which types in this
+	// clientset are registered.
+	Scheme = runtime.NewScheme()
+	// Codecs provides access to encoding and decoding for the scheme.
+	Codecs = serializer.NewCodecFactory(Scheme)
+	// ParameterCodec handles versioning of objects used in REST requests.
+	ParameterCodec = runtime.NewParameterCodec(Scheme)
+)
+
+func init() {
+	utilruntime.Must(clientgoscheme.AddToScheme(Scheme))
+	utilruntime.Must(schedulingv1alpha1.AddToScheme(Scheme))
+}
@@ -0,0 +1,123 @@
+package v1alpha1
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/rest"
+
+	v1alpha1 "k8s-scheduler/pkg/apis/scheduling/v1alpha1"
+	"k8s-scheduler/pkg/client/clientset/versioned/scheme"
+)
+
+// ElasticQuotasGetter has a method to return an ElasticQuotaInterface.
+type ElasticQuotasGetter interface {
+	ElasticQuotas(namespace string) ElasticQuotaInterface
+}
+
+// ElasticQuotaInterface has methods to work with ElasticQuota resources.
+type ElasticQuotaInterface interface {
+	Create(ctx context.Context, elasticQuota *v1alpha1.ElasticQuota, opts metav1.CreateOptions) (*v1alpha1.ElasticQuota, error)
+	Update(ctx context.Context, elasticQuota *v1alpha1.ElasticQuota, opts metav1.UpdateOptions) (*v1alpha1.ElasticQuota, error)
+	UpdateStatus(ctx context.Context, elasticQuota *v1alpha1.ElasticQuota, opts metav1.UpdateOptions) (*v1alpha1.ElasticQuota, error)
+	Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error
+	Get(ctx context.Context, name string, opts metav1.GetOptions) (*v1alpha1.ElasticQuota, error)
+	List(ctx context.Context, opts metav1.ListOptions) (*v1alpha1.ElasticQuotaList, error)
+	Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error)
+}
+
+// elasticQuotas implements ElasticQuotaInterface.
+type elasticQuotas struct {
+	client rest.Interface
+	ns     string
+}
+
+// newElasticQuotas returns an ElasticQuotas backed by the given client.
+func newElasticQuotas(c *SchedulingV1alpha1Client, namespace string) *elasticQuotas {
+	return &elasticQuotas{
+		client: c.RESTClient(),
+		ns:     namespace,
+	}
+}
+
+func (c *elasticQuotas) Get(ctx context.Context, name string, opts metav1.GetOptions) (result *v1alpha1.ElasticQuota, err error) {
+	result = &v1alpha1.ElasticQuota{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("elasticquotas").
+		Name(name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return result, err
+}
+
+func (c *elasticQuotas) List(ctx context.Context, opts metav1.ListOptions) (result *v1alpha1.ElasticQuotaList, err error) {
+	result = &v1alpha1.ElasticQuotaList{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("elasticquotas").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return result, err
+}
+
+func (c *elasticQuotas) Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error) {
+	opts.Watch = true
+	return c.client.Get().
+		Namespace(c.ns).
+		Resource("elasticquotas").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Watch(ctx)
+}
+
+func (c *elasticQuotas) Create(ctx context.Context, elasticQuota *v1alpha1.ElasticQuota, opts metav1.CreateOptions) (result *v1alpha1.ElasticQuota, err error) {
+	result = &v1alpha1.ElasticQuota{}
+	err = c.client.Post().
+		Namespace(c.ns).
+		Resource("elasticquotas").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(elasticQuota).
+		Do(ctx).
+		Into(result)
+	return result, err
+}
+
+func (c *elasticQuotas) Update(ctx context.Context, elasticQuota *v1alpha1.ElasticQuota, opts metav1.UpdateOptions) (result *v1alpha1.ElasticQuota, err error) {
+	result = &v1alpha1.ElasticQuota{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("elasticquotas").
+		Name(elasticQuota.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(elasticQuota).
+		Do(ctx).
+		Into(result)
+	return result, err
+}
+
+func (c *elasticQuotas) UpdateStatus(ctx context.Context, elasticQuota *v1alpha1.ElasticQuota, opts metav1.UpdateOptions) (result *v1alpha1.ElasticQuota, err error) {
+	result = &v1alpha1.ElasticQuota{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("elasticquotas").
+		Name(elasticQuota.Name).
+		SubResource("status").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(elasticQuota).
+		Do(ctx).
+		Into(result)
+	return result, err
+}
+
+func (c *elasticQuotas) Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error {
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("elasticquotas").
+		Name(name).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
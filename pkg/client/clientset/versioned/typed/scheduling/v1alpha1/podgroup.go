@@ -0,0 +1,123 @@
+package v1alpha1
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/rest"
+
+	v1alpha1 "k8s-scheduler/pkg/apis/scheduling/v1alpha1"
+	"k8s-scheduler/pkg/client/clientset/versioned/scheme"
+)
+
+// PodGroupsGetter has a method to return a PodGroupInterface.
+type PodGroupsGetter interface {
+	PodGroups(namespace string) PodGroupInterface
+}
+
+// PodGroupInterface has methods to work with PodGroup resources.
+type PodGroupInterface interface {
+	Create(ctx context.Context, podGroup *v1alpha1.PodGroup, opts metav1.CreateOptions) (*v1alpha1.PodGroup, error)
+	Update(ctx context.Context, podGroup *v1alpha1.PodGroup, opts metav1.UpdateOptions) (*v1alpha1.PodGroup, error)
+	UpdateStatus(ctx context.Context, podGroup *v1alpha1.PodGroup, opts metav1.UpdateOptions) (*v1alpha1.PodGroup, error)
+	Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error
+	Get(ctx context.Context, name string, opts metav1.GetOptions) (*v1alpha1.PodGroup, error)
+	List(ctx context.Context, opts metav1.ListOptions) (*v1alpha1.PodGroupList, error)
+	Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error)
+}
+
+// podGroups implements PodGroupInterface.
+type podGroups struct {
+	client rest.Interface
+	ns     string
+}
+
+// newPodGroups returns a PodGroups backed by the given client.
+func newPodGroups(c *SchedulingV1alpha1Client, namespace string) *podGroups {
+	return &podGroups{
+		client: c.RESTClient(),
+		ns:     namespace,
+	}
+}
+
+func (c *podGroups) Get(ctx context.Context, name string, opts metav1.GetOptions) (result *v1alpha1.PodGroup, err error) {
+	result = &v1alpha1.PodGroup{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("podgroups").
+		Name(name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return result, err
+}
+
+func (c *podGroups) List(ctx context.Context, opts metav1.ListOptions) (result *v1alpha1.PodGroupList, err error) {
+	result = &v1alpha1.PodGroupList{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("podgroups").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return result, err
+}
+
+func (c *podGroups) Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error) {
+	opts.Watch = true
+	return c.client.Get().
+		Namespace(c.ns).
+		Resource("podgroups").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Watch(ctx)
+}
+
+func (c *podGroups) Create(ctx context.Context, podGroup *v1alpha1.PodGroup, opts metav1.CreateOptions) (result *v1alpha1.PodGroup, err error) {
+	result = &v1alpha1.PodGroup{}
+	err = c.client.Post().
+		Namespace(c.ns).
+		Resource("podgroups").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(podGroup).
+		Do(ctx).
+		Into(result)
+	return result, err
+}
+
+func (c *podGroups) Update(ctx context.Context, podGroup *v1alpha1.PodGroup, opts metav1.UpdateOptions) (result *v1alpha1.PodGroup, err error) {
+	result = &v1alpha1.PodGroup{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("podgroups").
+		Name(podGroup.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(podGroup).
+		Do(ctx).
+		Into(result)
+	return result, err
+}
+
+func (c *podGroups) UpdateStatus(ctx context.Context, podGroup *v1alpha1.PodGroup, opts metav1.UpdateOptions) (result *v1alpha1.PodGroup, err error) {
+	result = &v1alpha1.PodGroup{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("podgroups").
+		Name(podGroup.Name).
+		SubResource("status").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(podGroup).
+		Do(ctx).
+		Into(result)
+	return result, err
+}
+
+func (c *podGroups) Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error {
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("podgroups").
+		Name(name).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
@@ -0,0 +1,64 @@
+package v1alpha1
+
+import (
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/client-go/rest"
+
+	schedulingv1alpha1 "k8s-scheduler/pkg/apis/scheduling/v1alpha1"
+	"k8s-scheduler/pkg/client/clientset/versioned/scheme"
+)
+
+// SchedulingV1alpha1Interface declares the operations supported by the
+// scheduling.k8s-scheduler.io/v1alpha1 client.
+type SchedulingV1alpha1Interface interface {
+	PodGroupsGetter
+	ElasticQuotasGetter
+}
+
+// SchedulingV1alpha1Client is used to interact with the
+// scheduling.k8s-scheduler.io/v1alpha1 API group.
+type SchedulingV1alpha1Client struct {
+	restClient rest.Interface
+}
+
+// PodGroups returns a PodGroupInterface scoped to the given namespace.
+func (c *SchedulingV1alpha1Client) PodGroups(namespace string) PodGroupInterface {
+	return newPodGroups(c, namespace)
+}
+
+// ElasticQuotas returns an ElasticQuotaInterface scoped to the given namespace.
+func (c *SchedulingV1alpha1Client) ElasticQuotas(namespace string) ElasticQuotaInterface {
+	return newElasticQuotas(c, namespace)
+}
+
+// NewForConfig creates a new SchedulingV1alpha1Client from the given config.
+func NewForConfig(c *rest.Config) (*SchedulingV1alpha1Client, error) {
+	config := *c
+	if err := setConfigDefaults(&config); err != nil {
+		return nil, err
+	}
+	restClient, err := rest.RESTClientFor(&config)
+	if err != nil {
+		return nil, err
+	}
+	return &SchedulingV1alpha1Client{restClient: restClient}, nil
+}
+
+func setConfigDefaults(config *rest.Config) error {
+	gv := schedulingv1alpha1.SchemeGroupVersion
+	config.GroupVersion = &gv
+	config.APIPath = "/apis"
+	config.NegotiatedSerializer = serializer.WithoutConversionCodecFactory{CodecFactory: scheme.Codecs}
+	if config.UserAgent == "" {
+		config.UserAgent = rest.DefaultKubernetesUserAgent()
+	}
+	return nil
+}
+
+// RESTClient returns the underlying REST client.
+func (c *SchedulingV1alpha1Client) RESTClient() rest.Interface {
+	if c == nil {
+		return nil
+	}
+	return c.restClient
+}
@@ -0,0 +1,224 @@
+// Package elasticquota implements a controller that tracks per-namespace
+// resource usage against ElasticQuota custom resources, so the
+// elasticquota scheduler plugin and BatchPermit can gate gang admission on
+// guaranteed and burstable capacity per tenant.
+package elasticquota
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+
+	schedulingv1alpha1 "k8s-scheduler/pkg/apis/scheduling/v1alpha1"
+	clientset "k8s-scheduler/pkg/client/clientset/versioned"
+	"k8s-scheduler/pkg/util"
+)
+
+// Controller watches ElasticQuotas and the pods in their member namespaces,
+// keeping each ElasticQuota's Status.Used in sync with what is actually
+// running in the cluster.
+type Controller struct {
+	kubeClient kubernetes.Interface
+	eqClient   clientset.Interface
+
+	podInformer          cache.SharedIndexInformer
+	elasticQuotaInformer cache.SharedIndexInformer
+
+	queue workqueue.RateLimitingInterface
+}
+
+// NewElasticQuotaListWatch returns a ListWatch over ElasticQuotas in all
+// namespaces, suitable for building a SharedIndexInformer without generated
+// listers.
+func NewElasticQuotaListWatch(eqClient clientset.Interface) *cache.ListWatch {
+	return &cache.ListWatch{
+		ListFunc: func(opts metav1.ListOptions) (runtime.Object, error) {
+			return eqClient.SchedulingV1alpha1().ElasticQuotas(metav1.NamespaceAll).List(context.Background(), opts)
+		},
+		WatchFunc: func(opts metav1.ListOptions) (watch.Interface, error) {
+			return eqClient.SchedulingV1alpha1().ElasticQuotas(metav1.NamespaceAll).Watch(context.Background(), opts)
+		},
+	}
+}
+
+// NewController builds a Controller wired to the given informers.
+func NewController(kubeClient kubernetes.Interface, eqClient clientset.Interface, podInformer, elasticQuotaInformer cache.SharedIndexInformer) *Controller {
+	c := &Controller{
+		kubeClient:           kubeClient,
+		eqClient:             eqClient,
+		podInformer:          podInformer,
+		elasticQuotaInformer: elasticQuotaInformer,
+		queue:                workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter()),
+	}
+
+	elasticQuotaInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(obj interface{}) { c.enqueueFromObject(obj) },
+		UpdateFunc: func(_, obj interface{}) { c.enqueueFromObject(obj) },
+		DeleteFunc: func(obj interface{}) { c.enqueueFromObject(obj) },
+	})
+
+	podInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(obj interface{}) { c.enqueueAllQuotas() },
+		UpdateFunc: func(old, obj interface{}) { c.enqueueAllQuotas() },
+		DeleteFunc: func(obj interface{}) { c.enqueueAllQuotas() },
+	})
+
+	return c
+}
+
+func (c *Controller) enqueueFromObject(obj interface{}) {
+	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+	if err != nil {
+		klog.ErrorS(err, "error building controller queue key")
+		return
+	}
+	c.queue.Add(key)
+}
+
+// enqueueAllQuotas re-syncs every ElasticQuota when a pod changes, since a
+// single pod event cannot tell us which quota's member namespaces it falls
+// under without first evaluating every quota's selector against it.
+func (c *Controller) enqueueAllQuotas() {
+	for _, obj := range c.elasticQuotaInformer.GetStore().List() {
+		c.enqueueFromObject(obj)
+	}
+}
+
+// Run starts the controller's workers and blocks until ctx is done.
+func (c *Controller) Run(ctx context.Context, workers int) error {
+	defer c.queue.ShutDown()
+
+	klog.InfoS("Starting ElasticQuota controller", "workers", workers)
+
+	if !cache.WaitForCacheSync(ctx.Done(), c.podInformer.HasSynced, c.elasticQuotaInformer.HasSynced) {
+		return fmt.Errorf("failed to wait for caches to sync")
+	}
+
+	for i := 0; i < workers; i++ {
+		go wait.UntilWithContext(ctx, c.runWorker, time.Second)
+	}
+
+	<-ctx.Done()
+	klog.InfoS("Shutting down ElasticQuota controller")
+	return nil
+}
+
+func (c *Controller) runWorker(ctx context.Context) {
+	for c.processNextWorkItem(ctx) {
+	}
+}
+
+func (c *Controller) processNextWorkItem(ctx context.Context) bool {
+	key, shutdown := c.queue.Get()
+	if shutdown {
+		return false
+	}
+	defer c.queue.Done(key)
+
+	if err := c.syncHandler(ctx, key.(string)); err != nil {
+		c.queue.AddRateLimited(key)
+		klog.ErrorS(err, "error syncing ElasticQuota", "key", key)
+		return true
+	}
+
+	c.queue.Forget(key)
+	return true
+}
+
+// syncHandler recomputes Status.Used for a single ElasticQuota from the
+// non-terminal pods running in its member namespaces.
+func (c *Controller) syncHandler(ctx context.Context, key string) error {
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return err
+	}
+
+	eq, err := c.eqClient.SchedulingV1alpha1().ElasticQuotas(namespace).Get(ctx, name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		klog.V(4).InfoS("ElasticQuota no longer exists", "key", key)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	members, err := c.memberNamespaces(ctx, eq)
+	if err != nil {
+		return err
+	}
+
+	used := v1.ResourceList{}
+	for _, ns := range members {
+		pods, err := c.kubeClient.CoreV1().Pods(ns).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return err
+		}
+		for i := range pods.Items {
+			pod := &pods.Items[i]
+			if pod.Status.Phase == v1.PodSucceeded || pod.Status.Phase == v1.PodFailed {
+				continue
+			}
+			util.AddInto(used, util.SumPodRequests(pod))
+		}
+	}
+
+	if resourceListEqual(eq.Status.Used, used) {
+		return nil
+	}
+
+	updated := eq.DeepCopy()
+	updated.Status.Used = used
+	_, err = c.eqClient.SchedulingV1alpha1().ElasticQuotas(namespace).UpdateStatus(ctx, updated, metav1.UpdateOptions{})
+	return err
+}
+
+// memberNamespaces returns eq's own namespace plus every namespace whose
+// labels match eq.Spec.Namespaces.
+func (c *Controller) memberNamespaces(ctx context.Context, eq *schedulingv1alpha1.ElasticQuota) ([]string, error) {
+	members := []string{eq.Namespace}
+	if eq.Spec.Namespaces == nil {
+		return members, nil
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(eq.Spec.Namespaces)
+	if err != nil {
+		return nil, fmt.Errorf("parsing namespaces selector: %w", err)
+	}
+
+	namespaces, err := c.kubeClient.CoreV1().Namespaces().List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return nil, err
+	}
+	for _, ns := range namespaces.Items {
+		if ns.Name != eq.Namespace {
+			members = append(members, ns.Name)
+		}
+	}
+	return members, nil
+}
+
+// resourceListEqual reports whether a and b contain the same quantities,
+// used to avoid writing a Status update that would not change anything.
+func resourceListEqual(a, b v1.ResourceList) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for name, qty := range a {
+		other, ok := b[name]
+		if !ok || qty.Cmp(other) != 0 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,211 @@
+// Package podgroup implements a controller that reconciles PodGroup custom
+// resources against the member pods they describe.
+package podgroup
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+
+	schedulingv1alpha1 "k8s-scheduler/pkg/apis/scheduling/v1alpha1"
+	clientset "k8s-scheduler/pkg/client/clientset/versioned"
+)
+
+// Controller watches PodGroups and their member pods and keeps PodGroup
+// status in sync with what is actually running in the cluster.
+type Controller struct {
+	kubeClient kubernetes.Interface
+	pgClient   clientset.Interface
+
+	podInformer      cache.SharedIndexInformer
+	podGroupInformer cache.SharedIndexInformer
+
+	queue workqueue.RateLimitingInterface
+}
+
+// NewPodGroupListWatch returns a ListWatch over PodGroups in all namespaces,
+// suitable for building a SharedIndexInformer without generated listers.
+func NewPodGroupListWatch(pgClient clientset.Interface) *cache.ListWatch {
+	return &cache.ListWatch{
+		ListFunc: func(opts metav1.ListOptions) (runtime.Object, error) {
+			return pgClient.SchedulingV1alpha1().PodGroups(metav1.NamespaceAll).List(context.Background(), opts)
+		},
+		WatchFunc: func(opts metav1.ListOptions) (watch.Interface, error) {
+			return pgClient.SchedulingV1alpha1().PodGroups(metav1.NamespaceAll).Watch(context.Background(), opts)
+		},
+	}
+}
+
+// NewController builds a Controller wired to the given informers.
+func NewController(kubeClient kubernetes.Interface, pgClient clientset.Interface, podInformer, podGroupInformer cache.SharedIndexInformer) *Controller {
+	c := &Controller{
+		kubeClient:       kubeClient,
+		pgClient:         pgClient,
+		podInformer:      podInformer,
+		podGroupInformer: podGroupInformer,
+		queue:            workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter()),
+	}
+
+	podGroupInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(obj interface{}) { c.enqueueFromObject(obj) },
+		UpdateFunc: func(_, obj interface{}) { c.enqueueFromObject(obj) },
+		DeleteFunc: func(obj interface{}) { c.enqueueFromObject(obj) },
+	})
+
+	podInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(obj interface{}) { c.enqueueFromPod(obj) },
+		UpdateFunc: func(_, obj interface{}) { c.enqueueFromPod(obj) },
+		DeleteFunc: func(obj interface{}) { c.enqueueFromPod(obj) },
+	})
+
+	return c
+}
+
+func (c *Controller) enqueueFromObject(obj interface{}) {
+	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+	if err != nil {
+		utilruntimeHandleError(err)
+		return
+	}
+	c.queue.Add(key)
+}
+
+func (c *Controller) enqueueFromPod(obj interface{}) {
+	pod, ok := obj.(*v1.Pod)
+	if !ok {
+		tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+		if !ok {
+			return
+		}
+		pod, ok = tombstone.Obj.(*v1.Pod)
+		if !ok {
+			return
+		}
+	}
+	name, ok := pod.Labels[schedulingv1alpha1.PodGroupLabelKey]
+	if !ok || name == "" {
+		return
+	}
+	c.queue.Add(fmt.Sprintf("%s/%s", pod.Namespace, name))
+}
+
+func utilruntimeHandleError(err error) {
+	klog.ErrorS(err, "error building controller queue key")
+}
+
+// Run starts the controller's workers and blocks until ctx is done.
+func (c *Controller) Run(ctx context.Context, workers int) error {
+	defer c.queue.ShutDown()
+
+	klog.InfoS("Starting PodGroup controller", "workers", workers)
+
+	if !cache.WaitForCacheSync(ctx.Done(), c.podInformer.HasSynced, c.podGroupInformer.HasSynced) {
+		return fmt.Errorf("failed to wait for caches to sync")
+	}
+
+	for i := 0; i < workers; i++ {
+		go wait.UntilWithContext(ctx, c.runWorker, time.Second)
+	}
+
+	<-ctx.Done()
+	klog.InfoS("Shutting down PodGroup controller")
+	return nil
+}
+
+func (c *Controller) runWorker(ctx context.Context) {
+	for c.processNextWorkItem(ctx) {
+	}
+}
+
+func (c *Controller) processNextWorkItem(ctx context.Context) bool {
+	key, shutdown := c.queue.Get()
+	if shutdown {
+		return false
+	}
+	defer c.queue.Done(key)
+
+	if err := c.syncHandler(ctx, key.(string)); err != nil {
+		c.queue.AddRateLimited(key)
+		klog.ErrorS(err, "error syncing PodGroup", "key", key)
+		return true
+	}
+
+	c.queue.Forget(key)
+	return true
+}
+
+// syncHandler reconciles a single PodGroup against its member pods. It only
+// writes the counts, defaults an empty phase to Pending, and sets the
+// Running/Unknown phases; Pending -> Inqueue is made by the scheduler plugins
+// (BatchEnqueue in PreEnqueue and BatchPermit in Permit), pre-bind once the
+// whole gang can fit, so this controller does not also drive that transition
+// from post-bind Scheduled counts.
+func (c *Controller) syncHandler(ctx context.Context, key string) error {
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return err
+	}
+
+	pg, err := c.pgClient.SchedulingV1alpha1().PodGroups(namespace).Get(ctx, name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		klog.V(4).InfoS("PodGroup no longer exists", "key", key)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	selector := labels.SelectorFromSet(labels.Set{schedulingv1alpha1.PodGroupLabelKey: name})
+	pods, err := c.kubeClient.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return err
+	}
+
+	status := schedulingv1alpha1.PodGroupStatus{Phase: pg.Status.Phase}
+	if status.Phase == "" {
+		status.Phase = schedulingv1alpha1.PodGroupPending
+	}
+
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if pod.Spec.NodeName != "" {
+			status.Scheduled++
+		}
+		switch pod.Status.Phase {
+		case v1.PodRunning:
+			status.Running++
+		case v1.PodSucceeded:
+			status.Succeeded++
+		case v1.PodFailed:
+			status.Failed++
+		}
+	}
+
+	if len(pods.Items) == 0 {
+		status.Phase = schedulingv1alpha1.PodGroupUnknown
+	}
+	if status.Running > 0 {
+		status.Phase = schedulingv1alpha1.PodGroupRunning
+	}
+
+	if status == pg.Status {
+		return nil
+	}
+
+	updated := pg.DeepCopy()
+	updated.Status = status
+	_, err = c.pgClient.SchedulingV1alpha1().PodGroups(namespace).UpdateStatus(ctx, updated, metav1.UpdateOptions{})
+	return err
+}
@@ -0,0 +1,68 @@
+// Package metrics exposes Prometheus metrics and a JSON introspection
+// endpoint for the batch scheduling plugins, following the kube-batch
+// pattern of registering collectors on the default registry and serving
+// them over a small dedicated HTTP listener.
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"k8s.io/klog/v2"
+)
+
+var (
+	// GroupsAdmittedTotal counts batch groups released by BatchPermit.
+	GroupsAdmittedTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "batchpermit_groups_admitted_total",
+		Help: "Total number of batch groups admitted (released) by the BatchPermit plugin.",
+	})
+
+	// GroupsTimeoutTotal counts batch groups that failed to be admitted
+	// before their Permit wait expired.
+	GroupsTimeoutTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "batchpermit_groups_timeout_total",
+		Help: "Total number of batch groups that timed out waiting for admission.",
+	})
+
+	// PodsWaiting is the current number of pods held in Permit across all
+	// batch groups.
+	PodsWaiting = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "batchpermit_pods_waiting",
+		Help: "Current number of pods held in Permit waiting for their batch group.",
+	})
+
+	// GroupWaitSeconds observes the time from a group's first Permit call to
+	// its release.
+	GroupWaitSeconds = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name:    "batchpermit_group_wait_seconds",
+		Help:    "Time from a batch group's first Permit call to its release.",
+		Buckets: prometheus.DefBuckets,
+	})
+
+	// GroupPhase is the current number of batch groups tracked in memory by
+	// BatchPermit in each lifecycle phase: a group not yet released counts as
+	// Pending and a released one as Inqueue. The Running and Unknown series
+	// are always zero.
+	GroupPhase = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "batchpermit_group_phase",
+		Help: "Number of batch groups tracked by the BatchPermit plugin in each lifecycle phase.",
+	}, []string{"phase"})
+)
+
+// Serve starts an HTTP server on addr exposing Prometheus metrics at
+// /metrics and, when groupsHandler is non-nil, batch group introspection at
+// /debug/groups. It blocks until the server stops and is meant to be run in
+// its own goroutine.
+func Serve(addr string, groupsHandler http.Handler) error {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+	if groupsHandler != nil {
+		mux.Handle("/debug/groups", groupsHandler)
+	}
+
+	klog.InfoS("Starting batch scheduling metrics server", "address", addr)
+	return http.ListenAndServe(addr, mux)
+}
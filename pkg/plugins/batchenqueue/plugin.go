@@ -0,0 +1,193 @@
+// Package batchenqueue implements the QueueSort and PreEnqueue extension
+// points for gang-scheduled pods. It orders the scheduling queue by pod
+// priority and then pod creation time, and refuses to start a scheduling cycle
+// for a member until the cluster has enough allocatable resources to
+// satisfy the whole group's MinResources. This avoids the wasted Permit
+// holds and batchpermit's 10-minute timeout that would otherwise follow
+// from admitting pods the cluster can never fit as a gang.
+package batchenqueue
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+	"k8s.io/kubernetes/pkg/scheduler/framework"
+
+	schedulingv1alpha1 "k8s-scheduler/pkg/apis/scheduling/v1alpha1"
+	clientset "k8s-scheduler/pkg/client/clientset/versioned"
+	"k8s-scheduler/pkg/util"
+)
+
+// Name is the plugin name used in the scheduler registry and configurations.
+const Name = "BatchEnqueue"
+
+// groupStateTTL bounds how long a group's fallback-required-resources entry
+// is kept since it was last touched. PreEnqueue has no signal for when a
+// gang finishes scheduling or is abandoned, so entries are aged out instead
+// of cleared eagerly, to bound memory in a long-running scheduler process
+// with many transient batch jobs that declare no MinResources.
+const groupStateTTL = 10 * time.Minute
+
+// ensure the plugin implements the required interfaces.
+var _ framework.QueueSortPlugin = &Plugin{}
+var _ framework.PreEnqueuePlugin = &Plugin{}
+
+// New returns a new instance of the plugin.
+func New(_ context.Context, handle framework.Handle, _ framework.PluginConfig) (framework.Plugin, error) {
+	pgClient, err := clientset.NewForConfig(handle.KubeConfig())
+	if err != nil {
+		return nil, fmt.Errorf("building PodGroup client: %w", err)
+	}
+
+	return &Plugin{
+		handle:     handle,
+		pgClient:   pgClient,
+		groupState: make(map[string]*groupState),
+	}, nil
+}
+
+// Plugin gates PodGroup members out of the scheduling queue until the
+// cluster can plausibly fit the whole gang, and orders the queue by priority
+// and then age, so among equal priorities older gangs are considered first.
+type Plugin struct {
+	mu         sync.Mutex
+	handle     framework.Handle
+	pgClient   clientset.Interface
+	groupState map[string]*groupState
+}
+
+// groupState tracks the resources requested by the PodGroup members seen so
+// far in PreEnqueue, used as a fallback when the PodGroup has no declared
+// MinResources.
+type groupState struct {
+	seen     map[string]bool
+	required v1.ResourceList
+	lastSeen time.Time
+}
+
+// Name returns the plugin name.
+func (p *Plugin) Name() string { return Name }
+
+// Less orders pods by Priority, as the default PrioritySort plugin does,
+// falling back to creation time so that among equal priorities older pods,
+// and by extension older gangs, are dequeued first. Only one QueueSortPlugin
+// can be active cluster-wide, so BatchEnqueue must preserve priority
+// ordering for every pod, not just PodGroup members, or enabling it would
+// silently regress priority scheduling cluster-wide.
+func (p *Plugin) Less(pInfo1, pInfo2 *framework.QueuedPodInfo) bool {
+	p1, p2 := podPriority(pInfo1.Pod), podPriority(pInfo2.Pod)
+	if p1 != p2 {
+		return p1 > p2
+	}
+	return pInfo1.Pod.CreationTimestamp.Before(&pInfo2.Pod.CreationTimestamp)
+}
+
+// podPriority returns pod's priority, defaulting to 0 for pods with no
+// PriorityClass.
+func podPriority(pod *v1.Pod) int32 {
+	if pod.Spec.Priority != nil {
+		return *pod.Spec.Priority
+	}
+	return 0
+}
+
+// PreEnqueue refuses to admit a PodGroup member into the scheduling queue
+// until the cluster's free node resources (allocatable minus already
+// requested) sum to at least the group's MinResources. Pods that do not
+// belong to a PodGroup are always admitted. When the PodGroup declares no
+// MinResources, the summed requests of the group's members seen so far are
+// required instead.
+func (p *Plugin) PreEnqueue(ctx context.Context, pod *v1.Pod) *framework.Status {
+	groupName, ok := pod.Labels[schedulingv1alpha1.PodGroupLabelKey]
+	if !ok || groupName == "" {
+		return framework.NewStatus(framework.Success, "")
+	}
+
+	required := p.requiredResources(ctx, pod, groupName)
+	if len(required) == 0 {
+		return framework.NewStatus(framework.Success, "")
+	}
+
+	if !p.clusterHasCapacity(required) {
+		klog.V(3).InfoS("Cluster cannot yet fit PodGroup's MinResources; holding pod out of the queue", "pod", klog.KObj(pod), "podGroup", klog.KRef(pod.Namespace, groupName), "required", required)
+		return framework.NewStatus(framework.Unschedulable, fmt.Sprintf("cluster does not yet have enough allocatable resources for PodGroup %s/%s", pod.Namespace, groupName))
+	}
+
+	if err := util.TransitionPodGroupPhase(ctx, p.pgClient, pod.Namespace, groupName, schedulingv1alpha1.PodGroupInqueue); err != nil {
+		klog.V(2).InfoS("Failed to transition PodGroup to Inqueue", "podGroup", klog.KRef(pod.Namespace, groupName), "err", err)
+	}
+	return framework.NewStatus(framework.Success, "")
+}
+
+// requiredResources returns the resources the PodGroup named groupName must
+// be able to fit before pod may enter the queue. It prefers the PodGroup's
+// declared MinResources; when none is set, it falls back to the sum of
+// requests across the group's members observed so far.
+func (p *Plugin) requiredResources(ctx context.Context, pod *v1.Pod, groupName string) v1.ResourceList {
+	if p.pgClient != nil {
+		pg, err := p.pgClient.SchedulingV1alpha1().PodGroups(pod.Namespace).Get(ctx, groupName, metav1.GetOptions{})
+		if err != nil {
+			klog.V(2).InfoS("Failed to resolve PodGroup for enqueue gate", "pod", klog.KObj(pod), "podGroup", klog.KRef(pod.Namespace, groupName), "err", err)
+		} else if len(pg.Spec.MinResources) > 0 {
+			return pg.Spec.MinResources
+		}
+	}
+
+	key := pod.Namespace + "/" + groupName
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.reapStaleGroupStateLocked()
+
+	st, exists := p.groupState[key]
+	if !exists {
+		st = &groupState{seen: make(map[string]bool), required: v1.ResourceList{}}
+		p.groupState[key] = st
+	}
+	if !st.seen[string(pod.UID)] {
+		st.seen[string(pod.UID)] = true
+		util.AddInto(st.required, util.SumPodRequests(pod))
+	}
+	st.lastSeen = time.Now()
+	return st.required
+}
+
+// reapStaleGroupStateLocked discards groupState entries not touched within
+// groupStateTTL. Caller must hold p.mu.
+func (p *Plugin) reapStaleGroupStateLocked() {
+	cutoff := time.Now().Add(-groupStateTTL)
+	for key, st := range p.groupState {
+		if st.lastSeen.Before(cutoff) {
+			delete(p.groupState, key)
+		}
+	}
+}
+
+// clusterHasCapacity reports whether the cluster's free node resources
+// (allocatable minus already-requested) sum to at least required.
+func (p *Plugin) clusterHasCapacity(required v1.ResourceList) bool {
+	snapshot := p.handle.SnapshotSharedLister()
+	if snapshot == nil {
+		return true
+	}
+
+	nodeInfos, err := snapshot.NodeInfos().List()
+	if err != nil {
+		klog.V(2).InfoS("Failed to list node infos for capacity check", "err", err)
+		return true
+	}
+
+	return util.Fits(required, util.SumNodeFree(nodeInfos))
+}
+
+// BuildConfig constructs the plugin config for use with the scheduler plugin registry.
+func BuildConfig() framework.PluginFactory {
+	return func(ctx context.Context, handle framework.Handle, cfg framework.PluginConfig) (framework.Plugin, error) {
+		return New(ctx, handle, cfg)
+	}
+}
@@ -0,0 +1,218 @@
+// Package hooks runs the Job-based lifecycle hooks declared on a PodGroup
+// at gang admission and eviction boundaries. It imports the ordered,
+// weighted hook execution model from the ONAP k8splugin hook system into
+// gang scheduling: hooks for an event run one at a time in ascending
+// Weight order, and the caller blocks until each hook's pods become Ready,
+// analogous to Helm's watchUntilReady.
+package hooks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+
+	batchv1 "k8s.io/api/batch/v1"
+
+	schedulingv1alpha1 "k8s-scheduler/pkg/apis/scheduling/v1alpha1"
+)
+
+const (
+	// defaultPreTimeout bounds pre-admit and pre-evict hooks that do not
+	// declare their own TimeoutSeconds.
+	defaultPreTimeout = 60 * time.Second
+	// defaultPostTimeout bounds post-admit and post-evict hooks that do not
+	// declare their own TimeoutSeconds.
+	defaultPostTimeout = 600 * time.Second
+
+	// hookAnnotationPrefix namespaces the per-event hook annotation, e.g.
+	// "batch.scheduling.k8s.io/hook-pre-admit", for callers who would rather
+	// not extend the PodGroup spec with a Hooks field.
+	hookAnnotationPrefix = "batch.scheduling.k8s.io/hook-"
+
+	podGroupHookLabel = "scheduling.k8s-scheduler.io/hook-podgroup"
+	eventHookLabel    = "scheduling.k8s-scheduler.io/hook-event"
+	nameHookLabel     = "scheduling.k8s-scheduler.io/hook-name"
+)
+
+// Runner creates and watches the Job hooks declared on a PodGroup.
+type Runner struct {
+	kubeClient kubernetes.Interface
+}
+
+// NewRunner returns a Runner that creates hook Jobs through kubeClient.
+func NewRunner(kubeClient kubernetes.Interface) *Runner {
+	return &Runner{kubeClient: kubeClient}
+}
+
+// Resolve returns the hook specs declared on pg for event, preferring
+// pg.Spec.Hooks and falling back to the "batch.scheduling.k8s.io/hook-<event>"
+// annotation (a JSON-encoded []HookSpec) when the spec declares none.
+func Resolve(pg *schedulingv1alpha1.PodGroup, event schedulingv1alpha1.PodGroupHookEvent) []schedulingv1alpha1.HookSpec {
+	if pg == nil {
+		return nil
+	}
+	if specs := pg.Spec.Hooks[event]; len(specs) > 0 {
+		return specs
+	}
+
+	raw, ok := pg.Annotations[hookAnnotationPrefix+string(event)]
+	if !ok || raw == "" {
+		return nil
+	}
+
+	var specs []schedulingv1alpha1.HookSpec
+	if err := json.Unmarshal([]byte(raw), &specs); err != nil {
+		klog.V(2).InfoS("Invalid hook annotation; ignoring", "podGroup", klog.KObj(pg), "event", event, "err", err)
+		return nil
+	}
+	return specs
+}
+
+// Run executes every hook in specs against the PodGroup named podGroupName,
+// in ascending Weight order, blocking until each hook's Job pods become
+// Ready or its timeout elapses. It returns the first error encountered,
+// which aborts any hooks still pending for this event.
+func (r *Runner) Run(ctx context.Context, namespace, podGroupName string, event schedulingv1alpha1.PodGroupHookEvent, specs []schedulingv1alpha1.HookSpec) error {
+	if r == nil || len(specs) == 0 {
+		return nil
+	}
+
+	ordered := append([]schedulingv1alpha1.HookSpec(nil), specs...)
+	sort.SliceStable(ordered, func(i, j int) bool { return ordered[i].Weight < ordered[j].Weight })
+
+	for _, spec := range ordered {
+		if err := r.runOne(ctx, namespace, podGroupName, event, spec); err != nil {
+			return fmt.Errorf("hook %q for PodGroup %s/%s event %s: %w", spec.Name, namespace, podGroupName, event, err)
+		}
+	}
+	return nil
+}
+
+// runOne creates spec's Job and blocks until its pods become Ready.
+func (r *Runner) runOne(ctx context.Context, namespace, podGroupName string, event schedulingv1alpha1.PodGroupHookEvent, spec schedulingv1alpha1.HookSpec) error {
+	labels := map[string]string{
+		podGroupHookLabel: podGroupName,
+		eventHookLabel:    string(event),
+		nameHookLabel:     spec.Name,
+	}
+
+	template := *spec.Template.DeepCopy()
+	template.Labels = mergeLabels(template.Labels, labels)
+
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: fmt.Sprintf("%s-%s-", podGroupName, spec.Name),
+			Namespace:    namespace,
+			Labels:       labels,
+		},
+		Spec: batchv1.JobSpec{Template: template},
+	}
+
+	created, err := r.kubeClient.BatchV1().Jobs(namespace).Create(ctx, job, metav1.CreateOptions{})
+	if err != nil {
+		return fmt.Errorf("creating hook job: %w", err)
+	}
+
+	timeout := time.Duration(spec.TimeoutSeconds) * time.Second
+	if timeout <= 0 {
+		timeout = defaultTimeout(event)
+	}
+
+	return r.watchUntilReady(ctx, created, timeout)
+}
+
+// watchUntilReady blocks, analogous to Helm's watchUntilReady, until every
+// pod owned by job reports Ready, the pod watch fails or closes, or timeout
+// elapses. The Job's own status is not inspected, so a failed job ends the
+// wait only through the timeout.
+func (r *Runner) watchUntilReady(ctx context.Context, job *batchv1.Job, timeout time.Duration) error {
+	waitCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	selector := fmt.Sprintf("job-name=%s", job.Name)
+
+	w, err := r.kubeClient.CoreV1().Pods(job.Namespace).Watch(waitCtx, metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return fmt.Errorf("watching hook job pods: %w", err)
+	}
+	defer w.Stop()
+
+	for {
+		ready, err := r.podsReady(waitCtx, job)
+		if err != nil {
+			return err
+		}
+		if ready {
+			return nil
+		}
+
+		select {
+		case <-waitCtx.Done():
+			return fmt.Errorf("hook job %s/%s did not become ready within %s", job.Namespace, job.Name, timeout)
+		case event, ok := <-w.ResultChan():
+			if !ok {
+				return fmt.Errorf("hook job %s/%s: pod watch closed before becoming ready", job.Namespace, job.Name)
+			}
+			if event.Type == watch.Error {
+				return fmt.Errorf("hook job %s/%s: pod watch error", job.Namespace, job.Name)
+			}
+		}
+	}
+}
+
+// podsReady reports whether job currently has at least one pod and every
+// pod it owns is Ready.
+func (r *Runner) podsReady(ctx context.Context, job *batchv1.Job) (bool, error) {
+	pods, err := r.kubeClient.CoreV1().Pods(job.Namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("job-name=%s", job.Name),
+	})
+	if err != nil {
+		return false, fmt.Errorf("listing hook job pods: %w", err)
+	}
+	if len(pods.Items) == 0 {
+		return false, nil
+	}
+	for i := range pods.Items {
+		if !podReady(&pods.Items[i]) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func podReady(pod *v1.Pod) bool {
+	for _, cond := range pod.Status.Conditions {
+		if cond.Type == v1.PodReady {
+			return cond.Status == v1.ConditionTrue
+		}
+	}
+	return false
+}
+
+func defaultTimeout(event schedulingv1alpha1.PodGroupHookEvent) time.Duration {
+	switch event {
+	case schedulingv1alpha1.HookPreAdmit, schedulingv1alpha1.HookPreEvict:
+		return defaultPreTimeout
+	default:
+		return defaultPostTimeout
+	}
+}
+
+func mergeLabels(base, overlay map[string]string) map[string]string {
+	merged := make(map[string]string, len(base)+len(overlay))
+	for k, v := range base {
+		merged[k] = v
+	}
+	for k, v := range overlay {
+		merged[k] = v
+	}
+	return merged
+}
@@ -2,15 +2,26 @@ package batchpermit
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
 	"strconv"
 	"sync"
 	"time"
 
 	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/kubernetes"
 	"k8s.io/klog/v2"
 	"k8s.io/kubernetes/pkg/scheduler/framework"
+
+	schedulingv1alpha1 "k8s-scheduler/pkg/apis/scheduling/v1alpha1"
+	clientset "k8s-scheduler/pkg/client/clientset/versioned"
+	"k8s-scheduler/pkg/metrics"
+	"k8s-scheduler/pkg/plugins/batchpermit/hooks"
+	"k8s-scheduler/pkg/plugins/elasticquota"
+	"k8s-scheduler/pkg/util"
 )
 
 const (
@@ -18,9 +29,13 @@ const (
 	Name = "BatchPermit"
 
 	// GroupAnnotation is the annotation key used to identify a batch group.
+	// Deprecated: prefer labeling pods with schedulingv1alpha1.PodGroupLabelKey
+	// and declaring the group's size via a PodGroup custom resource. Retained
+	// for backward compatibility with pods that predate the PodGroup CRD.
 	GroupAnnotation = "batch.scheduling.k8s.io/group"
 
 	// MinAvailableAnnotation defines the minimum number of pods required to start the batch.
+	// Deprecated: see GroupAnnotation.
 	MinAvailableAnnotation = "batch.scheduling.k8s.io/min-available"
 
 	// defaultPermitTimeout is the default time to wait for a gang to become schedulable.
@@ -33,25 +48,72 @@ var _ framework.PostBindPlugin = &Plugin{}
 var _ framework.UnreservePlugin = &Plugin{}
 
 // New returns a new instance of the plugin.
-func New(_ context.Context, handle framework.Handle, _ framework.PluginConfig) (framework.Plugin, error) {
-	return &Plugin{
-		handle:     handle,
-		groupState: make(map[string]*state),
-	}, nil
+func New(ctx context.Context, handle framework.Handle, _ framework.PluginConfig) (framework.Plugin, error) {
+	pgClient, err := clientset.NewForConfig(handle.KubeConfig())
+	if err != nil {
+		return nil, fmt.Errorf("building PodGroup client: %w", err)
+	}
+
+	kubeClient, err := kubernetes.NewForConfig(handle.KubeConfig())
+	if err != nil {
+		return nil, fmt.Errorf("building kube client: %w", err)
+	}
+
+	p := &Plugin{
+		handle:       handle,
+		pgClient:     pgClient,
+		hookRunner:   hooks.NewRunner(kubeClient),
+		quotaChecker: elasticquota.NewChecker(ctx, pgClient, kubeClient),
+		groupState:   make(map[string]*state),
+	}
+
+	instanceMu.Lock()
+	instance = p
+	instanceMu.Unlock()
+
+	return p, nil
 }
 
+// instance and instanceMu track the most recently constructed Plugin so
+// DebugGroupsHandler can serve its state without the caller needing a
+// reference to the scheduler's internal plugin registry. The scheduler
+// constructs exactly one BatchPermit plugin per process, so a singleton is
+// sufficient here.
+var (
+	instanceMu sync.Mutex
+	instance   *Plugin
+)
+
 // Plugin coordinates batch scheduling by holding pods until enough peers in the same group are ready to start.
 type Plugin struct {
-	mu         sync.Mutex
-	handle     framework.Handle
-	groupState map[string]*state
+	mu           sync.Mutex
+	handle       framework.Handle
+	pgClient     clientset.Interface
+	hookRunner   *hooks.Runner
+	quotaChecker *elasticquota.Checker
+	groupState   map[string]*state
 }
 
 // state tracks the waiting pods and the expected size of the batch.
 type state struct {
+	namespace    string // the gang's namespace, used to resolve its ElasticQuota
 	minAvailable int
+	minResources v1.ResourceList
+	podGroupName string           // set when the group is backed by a PodGroup CR, empty for annotation-only groups
 	waiting      sets.Set[string] // pod UIDs currently waiting in Permit phase
 	started      bool             // whether the gang has been released
+
+	// lastScheduleTime is the earliest Permit time across the gang's pods
+	// since the last failed cycle. It orders release among gangs that have
+	// all individually reached minAvailable: the oldest goes first. It is
+	// reset to now on Unreserve so a gang that just failed goes to the back
+	// of the queue instead of starving newer gangs.
+	lastScheduleTime time.Time
+
+	// firstPermitTime is set once, when the group is first seen, and never
+	// reset. It feeds the batchpermit_group_wait_seconds metric and the
+	// /debug/groups age field.
+	firstPermitTime time.Time
 }
 
 // Name returns the plugin name.
@@ -59,74 +121,221 @@ func (p *Plugin) Name() string { return Name }
 
 // Permit is invoked before binding a pod. It holds the pod if the batch has not reached the minimum size.
 func (p *Plugin) Permit(ctx context.Context, pod *v1.Pod, nodeName string) (*framework.Status, time.Duration) {
-	group, minAvailable, ok := getGroupInfo(pod)
+	key, minAvailable, minResources, podGroupName, ok := p.resolveGroup(ctx, pod)
 	if !ok {
 		return framework.NewStatus(framework.Success, "pod does not participate in batch scheduling"), 0
 	}
 
 	p.mu.Lock()
-	defer p.mu.Unlock()
 
-	key := fmt.Sprintf("%s/%s", pod.Namespace, group)
 	st, exists := p.groupState[key]
 	if !exists {
 		st = &state{
-			minAvailable: minAvailable,
-			waiting:      sets.New[string](),
+			namespace:       pod.Namespace,
+			minAvailable:    minAvailable,
+			minResources:    minResources,
+			podGroupName:    podGroupName,
+			waiting:         sets.New[string](),
+			firstPermitTime: time.Now(),
 		}
 		p.groupState[key] = st
 	}
 
 	if st.minAvailable != minAvailable {
-		// reconcile with the latest pod annotation to avoid drift.
+		// reconcile with the latest source of truth to avoid drift.
 		st.minAvailable = minAvailable
 	}
+	st.minResources = minResources
+	if st.lastScheduleTime.IsZero() {
+		st.lastScheduleTime = time.Now()
+	}
 
 	st.waiting.Insert(string(pod.UID))
+	p.recomputeGaugesLocked()
 
 	readyCount := len(st.waiting)
 
-	if st.started || readyCount >= st.minAvailable {
-		if !st.started {
-			st.started = true
-			p.releaseGroupLocked(key, st)
-		}
+	if st.started {
+		p.mu.Unlock()
 		klog.V(2).InfoS("Releasing batch", "group", key, "waiting", readyCount)
 		return framework.NewStatus(framework.Success, "batch size satisfied"), 0
 	}
 
-	klog.V(3).InfoS("Holding pod for batch", "pod", klog.KObj(pod), "group", key, "minAvailable", st.minAvailable, "current", readyCount)
-	return framework.NewStatus(framework.Wait, fmt.Sprintf("waiting for %d more pods in group %s", st.minAvailable-readyCount, group)), defaultPermitTimeout
+	if readyCount < st.minAvailable {
+		p.mu.Unlock()
+		klog.V(3).InfoS("Holding pod for batch", "pod", klog.KObj(pod), "group", key, "minAvailable", st.minAvailable, "current", readyCount)
+		return framework.NewStatus(framework.Wait, fmt.Sprintf("waiting for %d more pods in group %s", st.minAvailable-readyCount, key)), defaultPermitTimeout
+	}
+
+	if !p.isOldestReadyGroupLocked(ctx, key) {
+		p.mu.Unlock()
+		klog.V(3).InfoS("Gang size satisfied but an older or more quota-starved gang is waiting; deferring to preserve fairness", "group", key)
+		return framework.NewStatus(framework.Wait, fmt.Sprintf("waiting for an older gang to be admitted before group %s", key)), defaultPermitTimeout
+	}
+
+	if len(st.minResources) > 0 && !p.clusterHasCapacity(st.minResources) {
+		p.mu.Unlock()
+		klog.V(3).InfoS("Gang size satisfied but cluster cannot fit MinResources yet", "group", key, "minResources", st.minResources)
+		return framework.NewStatus(framework.Wait, fmt.Sprintf("waiting for cluster capacity to fit group %s", key)), defaultPermitTimeout
+	}
+
+	if fits, err := p.quotaChecker.Fits(ctx, st.namespace, st.minResources); err != nil {
+		klog.V(2).InfoS("Failed to resolve ElasticQuota; admitting gang", "group", key, "err", err)
+	} else if !fits {
+		p.mu.Unlock()
+		klog.V(3).InfoS("Gang size satisfied but namespace would exceed its ElasticQuota Max", "group", key, "namespace", st.namespace)
+		return framework.NewStatus(framework.Wait, fmt.Sprintf("waiting for ElasticQuota headroom to admit group %s", key)), defaultPermitTimeout
+	}
+
+	st.started = true
+	metrics.GroupsAdmittedTotal.Inc()
+	metrics.GroupWaitSeconds.Observe(time.Since(st.firstPermitTime).Seconds())
+	p.recomputeGaugesLocked()
+	namespace, podGroupName := pod.Namespace, st.podGroupName
+	p.mu.Unlock()
+
+	if podGroupName != "" {
+		p.transitionToInqueue(ctx, namespace, podGroupName)
+	}
+
+	// Run the pre-admit hook without p.mu held: it can block on a live
+	// watch for up to the hook's timeout, and every other Permit/Unreserve
+	// call for every other gang is serialized behind this same lock.
+	if err := p.runHookEvent(ctx, namespace, podGroupName, schedulingv1alpha1.HookPreAdmit); err != nil {
+		klog.ErrorS(err, "Pre-admit hook failed; failing group", "group", key, "podGroup", podGroupName)
+		p.mu.Lock()
+		if cur, ok := p.groupState[key]; ok && cur == st {
+			p.failGroupLocked(ctx, namespace, key, st, err)
+		}
+		p.mu.Unlock()
+		p.sweepDeferredGroups(ctx)
+		return framework.NewStatus(framework.Unschedulable, fmt.Sprintf("pre-admit hook failed for group %s: %v", key, err)), 0
+	}
+
+	p.mu.Lock()
+	if cur, ok := p.groupState[key]; ok && cur == st {
+		p.releaseGroupLocked(key, st)
+	}
+	p.mu.Unlock()
+	klog.V(2).InfoS("Releasing batch", "group", key, "waiting", readyCount)
+	p.sweepDeferredGroups(ctx)
+	return framework.NewStatus(framework.Success, "batch size satisfied"), 0
 }
 
 // PostBind cleans up internal state after the pod has been bound.
 func (p *Plugin) PostBind(ctx context.Context, state *framework.CycleState, pod *v1.Pod, nodeName string) {
-	p.cleanup(pod)
+	p.cleanup(ctx, pod)
 }
 
-// Unreserve is invoked when a reserved pod is rejected. We clean up the state to avoid leaking entries.
+// Unreserve is invoked when a reserved pod is rejected, including when its
+// Permit wait times out. We clean up the pod's entry and send the whole gang
+// to the back of the fairness queue so a gang that just failed doesn't keep
+// starving others on the next cycle. When the last waiting member is torn
+// down, pre-evict and post-evict hooks run around the state wipe.
 func (p *Plugin) Unreserve(ctx context.Context, state *framework.CycleState, pod *v1.Pod, nodeName string) {
-	p.cleanup(pod)
+	key, podGroupName, ok := groupKeyAndName(pod)
+	if !ok {
+		return
+	}
+	p.mu.Lock()
+
+	st, exists := p.groupState[key]
+	if !exists {
+		p.mu.Unlock()
+		return
+	}
+
+	// A gang that just failed, timed out, or lost a member changes who the
+	// oldest ready gang is, so give every other deferred-but-ready gang a
+	// chance to be admitted instead of waiting for its own next Permit call.
+	defer p.sweepDeferredGroups(ctx)
+
+	st.waiting.Delete(string(pod.UID))
+	if st.waiting.Len() == 0 {
+		if !st.started {
+			metrics.GroupsTimeoutTotal.Inc()
+		}
+		p.mu.Unlock()
+		if err := p.runHookEvent(ctx, pod.Namespace, podGroupName, schedulingv1alpha1.HookPreEvict); err != nil {
+			klog.V(2).InfoS("Pre-evict hook failed; wiping group state anyway", "group", key, "podGroup", podGroupName, "err", err)
+		}
+
+		p.mu.Lock()
+		delete(p.groupState, key)
+		p.recomputeGaugesLocked()
+		p.mu.Unlock()
+
+		if err := p.runHookEvent(ctx, pod.Namespace, podGroupName, schedulingv1alpha1.HookPostEvict); err != nil {
+			klog.V(2).InfoS("Post-evict hook failed", "group", key, "podGroup", podGroupName, "err", err)
+		}
+		return
+	}
+	st.lastScheduleTime = time.Now()
+	p.recomputeGaugesLocked()
+	p.mu.Unlock()
 }
 
-func (p *Plugin) cleanup(pod *v1.Pod) {
-	group, _, ok := getGroupInfo(pod)
+func (p *Plugin) cleanup(ctx context.Context, pod *v1.Pod) {
+	key, podGroupName, ok := groupKeyAndName(pod)
 	if !ok {
 		return
 	}
-	key := fmt.Sprintf("%s/%s", pod.Namespace, group)
 	p.mu.Lock()
-	defer p.mu.Unlock()
 
 	st, exists := p.groupState[key]
 	if !exists {
+		p.mu.Unlock()
 		return
 	}
 
 	st.waiting.Delete(string(pod.UID))
-	if st.waiting.Len() == 0 {
+	drained := st.waiting.Len() == 0
+	if drained {
 		delete(p.groupState, key)
 	}
+	p.recomputeGaugesLocked()
+	p.mu.Unlock()
+
+	if drained {
+		if err := p.runHookEvent(ctx, pod.Namespace, podGroupName, schedulingv1alpha1.HookPostAdmit); err != nil {
+			klog.V(2).InfoS("Post-admit hook failed", "group", key, "podGroup", podGroupName, "err", err)
+		}
+	}
+}
+
+// isOldestReadyGroupLocked reports whether the gang at key should be
+// released next among gangs that have reached their minAvailable but not
+// yet started. A gang whose namespace is below its ElasticQuota Min always
+// takes priority over one that has already reached its Min; among gangs
+// with the same below-Min standing, the oldest lastScheduleTime goes first.
+// Caller must hold p.mu.
+func (p *Plugin) isOldestReadyGroupLocked(ctx context.Context, key string) bool {
+	st := p.groupState[key]
+	stBelowMin, err := p.quotaChecker.BelowMin(ctx, st.namespace)
+	if err != nil {
+		klog.V(2).InfoS("Failed to resolve ElasticQuota for fairness ordering", "group", key, "err", err)
+	}
+
+	for otherKey, other := range p.groupState {
+		if otherKey == key || other.started {
+			continue
+		}
+		if other.waiting.Len() < other.minAvailable {
+			continue
+		}
+
+		otherBelowMin, err := p.quotaChecker.BelowMin(ctx, other.namespace)
+		if err != nil {
+			klog.V(2).InfoS("Failed to resolve ElasticQuota for fairness ordering", "group", otherKey, "err", err)
+		}
+		if otherBelowMin && !stBelowMin {
+			return false
+		}
+		if otherBelowMin == stBelowMin && other.lastScheduleTime.Before(st.lastScheduleTime) {
+			return false
+		}
+	}
+	return true
 }
 
 // releaseGroupLocked releases all waiting pods that belong to the provided batch key.
@@ -140,12 +349,8 @@ func (p *Plugin) releaseGroupLocked(groupKey string, st *state) {
 			return
 		}
 
-		g, _, ok := getGroupInfo(pod)
-		if !ok {
-			return
-		}
-		key := fmt.Sprintf("%s/%s", pod.Namespace, g)
-		if key != groupKey {
+		key, _, ok := groupKeyAndName(pod)
+		if !ok || key != groupKey {
 			return
 		}
 		if !st.waiting.Has(string(pod.UID)) {
@@ -159,7 +364,276 @@ func (p *Plugin) releaseGroupLocked(groupKey string, st *state) {
 	klog.V(2).InfoS("Allowed waiting batch pods", "group", groupKey, "count", released)
 }
 
-// getGroupInfo extracts the batch group metadata from pod annotations.
+// runHookEvent runs the hooks declared for event on the named PodGroup,
+// blocking until they become Ready or fail. It is a no-op when the group has
+// no backing PodGroup or declares no hooks for event. It only reads through
+// p.pgClient and p.hookRunner, so it is safe to call with or without p.mu held.
+func (p *Plugin) runHookEvent(ctx context.Context, namespace, podGroupName string, event schedulingv1alpha1.PodGroupHookEvent) error {
+	if podGroupName == "" {
+		return nil
+	}
+
+	pg, err := p.pgClient.SchedulingV1alpha1().PodGroups(namespace).Get(ctx, podGroupName, metav1.GetOptions{})
+	if err != nil {
+		klog.V(2).InfoS("Failed to resolve PodGroup for hooks; skipping", "podGroup", klog.KRef(namespace, podGroupName), "event", event, "err", err)
+		return nil
+	}
+
+	specs := hooks.Resolve(pg, event)
+	if len(specs) == 0 {
+		return nil
+	}
+
+	return p.hookRunner.Run(ctx, namespace, podGroupName, event, specs)
+}
+
+// failGroupLocked rejects every waiting pod belonging to groupKey, transitions
+// its PodGroup to Failed, and discards the group's state. Caller must hold p.mu.
+func (p *Plugin) failGroupLocked(ctx context.Context, namespace, groupKey string, st *state, cause error) {
+	waitingPods := p.handle.WaitingPods()
+	waitingPods.Iterate(func(wp framework.WaitingPod) {
+		pod := wp.GetPod()
+		if pod == nil {
+			return
+		}
+		key, _, ok := groupKeyAndName(pod)
+		if !ok || key != groupKey {
+			return
+		}
+		wp.Reject(p.Name(), cause.Error())
+	})
+
+	if st.podGroupName != "" {
+		if err := util.TransitionPodGroupPhase(ctx, p.pgClient, namespace, st.podGroupName, schedulingv1alpha1.PodGroupFailed); err != nil {
+			klog.V(2).InfoS("Failed to transition PodGroup to Failed", "podGroup", klog.KRef(namespace, st.podGroupName), "err", err)
+		}
+	}
+
+	delete(p.groupState, groupKey)
+	metrics.GroupsTimeoutTotal.Inc()
+	p.recomputeGaugesLocked()
+}
+
+// sweepDeferredGroups re-evaluates every gang that has reached its
+// minAvailable but is still waiting, admitting whichever is now the oldest
+// ready gang. Without this, a gang deferred by isOldestReadyGroupLocked in
+// favor of an older gang would never be reconsidered until its own
+// defaultPermitTimeout elapsed and the framework force-rejected it, even
+// after the older gang it was waiting on started, failed, or timed out.
+// Callers trigger it after exactly those events.
+func (p *Plugin) sweepDeferredGroups(ctx context.Context) {
+	p.mu.Lock()
+	keys := make([]string, 0, len(p.groupState))
+	for key, st := range p.groupState {
+		if !st.started && st.waiting.Len() >= st.minAvailable {
+			keys = append(keys, key)
+		}
+	}
+	p.mu.Unlock()
+
+	for _, key := range keys {
+		p.tryAdmitGroup(ctx, key)
+	}
+}
+
+// tryAdmitGroup admits the gang at key if it is still ready and is now the
+// oldest ready gang, running the same capacity, quota and pre-admit hook
+// gates as Permit's own admission path. Unlike Permit, there is no single
+// pod whose own Permit call is in flight to carry the admission forward, so
+// releaseGroupLocked must release every waiting member, which sweep callers
+// only invoke once all of them are already parked in WaitingPods().
+func (p *Plugin) tryAdmitGroup(ctx context.Context, key string) {
+	p.mu.Lock()
+	st, exists := p.groupState[key]
+	if !exists || st.started || st.waiting.Len() < st.minAvailable {
+		p.mu.Unlock()
+		return
+	}
+	if !p.isOldestReadyGroupLocked(ctx, key) {
+		p.mu.Unlock()
+		return
+	}
+	if len(st.minResources) > 0 && !p.clusterHasCapacity(st.minResources) {
+		p.mu.Unlock()
+		return
+	}
+	if fits, err := p.quotaChecker.Fits(ctx, st.namespace, st.minResources); err != nil {
+		klog.V(2).InfoS("Failed to resolve ElasticQuota; admitting gang", "group", key, "err", err)
+	} else if !fits {
+		p.mu.Unlock()
+		return
+	}
+
+	st.started = true
+	metrics.GroupsAdmittedTotal.Inc()
+	metrics.GroupWaitSeconds.Observe(time.Since(st.firstPermitTime).Seconds())
+	p.recomputeGaugesLocked()
+	namespace, podGroupName := st.namespace, st.podGroupName
+	waiting := st.waiting.Len()
+	p.mu.Unlock()
+
+	if podGroupName != "" {
+		p.transitionToInqueue(ctx, namespace, podGroupName)
+	}
+
+	if err := p.runHookEvent(ctx, namespace, podGroupName, schedulingv1alpha1.HookPreAdmit); err != nil {
+		klog.ErrorS(err, "Pre-admit hook failed; failing group", "group", key, "podGroup", podGroupName)
+		p.mu.Lock()
+		if cur, ok := p.groupState[key]; ok && cur == st {
+			p.failGroupLocked(ctx, namespace, key, st, err)
+		}
+		p.mu.Unlock()
+		return
+	}
+
+	p.mu.Lock()
+	if cur, ok := p.groupState[key]; ok && cur == st {
+		p.releaseGroupLocked(key, st)
+	}
+	p.mu.Unlock()
+	klog.V(2).InfoS("Releasing batch", "group", key, "waiting", waiting)
+}
+
+// resolveGroup determines the batch key, minimum size and minimum resources
+// for pod. It prefers a PodGroup custom resource resolved via the
+// schedulingv1alpha1.PodGroupLabelKey label and falls back to the legacy
+// annotations when no PodGroup is found.
+func (p *Plugin) resolveGroup(ctx context.Context, pod *v1.Pod) (key string, minAvailable int, minResources v1.ResourceList, podGroupName string, ok bool) {
+	key, podGroupName, ok = groupKeyAndName(pod)
+	if !ok {
+		return "", 0, nil, "", false
+	}
+
+	if podGroupName != "" && p.pgClient != nil {
+		pg, err := p.pgClient.SchedulingV1alpha1().PodGroups(pod.Namespace).Get(ctx, podGroupName, metav1.GetOptions{})
+		if err == nil {
+			return key, int(pg.Spec.MinMember), pg.Spec.MinResources, podGroupName, true
+		}
+		klog.V(2).InfoS("Failed to resolve PodGroup; falling back to annotations", "pod", klog.KObj(pod), "podGroup", podGroupName, "err", err)
+	}
+
+	_, minAvailable, annOK := getGroupInfo(pod)
+	if !annOK {
+		return "", 0, nil, "", false
+	}
+	return key, minAvailable, nil, "", true
+}
+
+// groupKeyAndName computes the namespaced batch key for pod and, when the pod
+// carries the PodGroup label, the name of the backing PodGroup.
+func groupKeyAndName(pod *v1.Pod) (key, podGroupName string, ok bool) {
+	if name, labeled := pod.Labels[schedulingv1alpha1.PodGroupLabelKey]; labeled && name != "" {
+		return fmt.Sprintf("%s/%s", pod.Namespace, name), name, true
+	}
+
+	group, _, annOK := getGroupInfo(pod)
+	if !annOK {
+		return "", "", false
+	}
+	return fmt.Sprintf("%s/%s", pod.Namespace, group), "", true
+}
+
+// clusterHasCapacity reports whether the cluster's free node resources
+// (allocatable minus already-requested) sum to at least required.
+func (p *Plugin) clusterHasCapacity(required v1.ResourceList) bool {
+	snapshot := p.handle.SnapshotSharedLister()
+	if snapshot == nil {
+		return true
+	}
+
+	nodeInfos, err := snapshot.NodeInfos().List()
+	if err != nil {
+		klog.V(2).InfoS("Failed to list node infos for capacity check", "err", err)
+		return true
+	}
+
+	return util.Fits(required, util.SumNodeFree(nodeInfos))
+}
+
+// recomputeGaugesLocked refreshes the PodsWaiting and GroupPhase gauges from
+// the current group state. Caller must hold p.mu.
+func (p *Plugin) recomputeGaugesLocked() {
+	waitingPods := 0
+	phaseCounts := map[schedulingv1alpha1.PodGroupPhase]float64{}
+	for _, st := range p.groupState {
+		waitingPods += st.waiting.Len()
+		phase := schedulingv1alpha1.PodGroupPending
+		if st.started {
+			phase = schedulingv1alpha1.PodGroupInqueue
+		}
+		phaseCounts[phase]++
+	}
+
+	metrics.PodsWaiting.Set(float64(waitingPods))
+	for _, phase := range []schedulingv1alpha1.PodGroupPhase{
+		schedulingv1alpha1.PodGroupPending,
+		schedulingv1alpha1.PodGroupInqueue,
+		schedulingv1alpha1.PodGroupRunning,
+		schedulingv1alpha1.PodGroupUnknown,
+	} {
+		metrics.GroupPhase.WithLabelValues(string(phase)).Set(phaseCounts[phase])
+	}
+}
+
+// GroupSnapshot is the JSON view of a batch group's state served at
+// /debug/groups so operators can spot stuck gangs.
+type GroupSnapshot struct {
+	Key          string   `json:"key"`
+	MinAvailable int      `json:"minAvailable"`
+	Waiting      []string `json:"waiting"`
+	Started      bool     `json:"started"`
+	AgeSeconds   float64  `json:"ageSeconds"`
+}
+
+// Groups returns a point-in-time snapshot of every batch group currently
+// tracked by the plugin.
+func (p *Plugin) Groups() []GroupSnapshot {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	snapshots := make([]GroupSnapshot, 0, len(p.groupState))
+	for key, st := range p.groupState {
+		snapshots = append(snapshots, GroupSnapshot{
+			Key:          key,
+			MinAvailable: st.minAvailable,
+			Waiting:      st.waiting.UnsortedList(),
+			Started:      st.started,
+			AgeSeconds:   time.Since(st.firstPermitTime).Seconds(),
+		})
+	}
+	return snapshots
+}
+
+// DebugGroupsHandler serves a JSON dump of the most recently constructed
+// Plugin's in-memory group state, for wiring into pkg/metrics.Serve's
+// /debug/groups endpoint from main.
+func DebugGroupsHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		instanceMu.Lock()
+		p := instance
+		instanceMu.Unlock()
+
+		groups := []GroupSnapshot{}
+		if p != nil {
+			groups = p.Groups()
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(groups); err != nil {
+			klog.V(2).InfoS("Failed to encode /debug/groups response", "err", err)
+		}
+	})
+}
+
+// transitionToInqueue marks the named PodGroup as Inqueue once its gang has
+// been admitted, so operators can observe that the group is being released.
+func (p *Plugin) transitionToInqueue(ctx context.Context, namespace, name string) {
+	if err := util.TransitionPodGroupPhase(ctx, p.pgClient, namespace, name, schedulingv1alpha1.PodGroupInqueue); err != nil {
+		klog.V(2).InfoS("Failed to transition PodGroup to Inqueue", "podGroup", klog.KRef(namespace, name), "err", err)
+	}
+}
+
+// getGroupInfo extracts the batch group metadata from the legacy pod annotations.
 func getGroupInfo(pod *v1.Pod) (group string, minAvailable int, ok bool) {
 	group, ok = pod.Annotations[GroupAnnotation]
 	if !ok || group == "" {
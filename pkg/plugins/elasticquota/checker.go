@@ -0,0 +1,176 @@
+// Package elasticquota implements the PreFilter/Filter gate that keeps a
+// namespace's ElasticQuota usage under its declared Max, together with a
+// PostFilter reclaim path that lets a namespace below its Min preempt pods
+// from namespaces that already have at least their Min. The Checker type is
+// also consumed directly by BatchPermit, which needs the same Max/Min
+// answers to gate and order gang release.
+package elasticquota
+
+import (
+	"context"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+
+	schedulingv1alpha1 "k8s-scheduler/pkg/apis/scheduling/v1alpha1"
+	clientset "k8s-scheduler/pkg/client/clientset/versioned"
+	"k8s-scheduler/pkg/util"
+)
+
+// checkerResyncPeriod is the resync interval for the informers backing a
+// Checker, matching cmd/controller's default --resync.
+const checkerResyncPeriod = 30 * time.Second
+
+// Checker resolves the ElasticQuota governing a namespace and answers
+// whether that namespace still has headroom under its Max, and whether it
+// is still below its Min and so owed fairness priority. It is backed by
+// informer caches rather than live apiserver calls, since both the
+// ElasticQuota and BatchPermit plugins consult it from hot, lock-serialized
+// paths.
+type Checker struct {
+	quotaInformer cache.SharedIndexInformer
+	nsLister      corev1listers.NamespaceLister
+}
+
+// NewChecker builds a Checker backed by informer caches over ElasticQuotas
+// and Namespaces, starts them, and blocks until their initial sync
+// completes or ctx is done. A nil eqClient is valid and yields a Checker
+// that treats every namespace as unquota'd without starting any informer.
+// ctx governs the informers' lifetime; the caller must keep it alive for as
+// long as the Checker is used.
+func NewChecker(ctx context.Context, eqClient clientset.Interface, kubeClient kubernetes.Interface) *Checker {
+	if eqClient == nil {
+		return &Checker{}
+	}
+
+	c := &Checker{
+		quotaInformer: cache.NewSharedIndexInformer(
+			newElasticQuotaListWatch(eqClient),
+			&schedulingv1alpha1.ElasticQuota{},
+			checkerResyncPeriod,
+			cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc},
+		),
+	}
+
+	nsInformers := informers.NewSharedInformerFactory(kubeClient, checkerResyncPeriod)
+	nsInformer := nsInformers.Core().V1().Namespaces()
+	c.nsLister = nsInformer.Lister()
+
+	go c.quotaInformer.Run(ctx.Done())
+	nsInformers.Start(ctx.Done())
+
+	if !cache.WaitForCacheSync(ctx.Done(), c.quotaInformer.HasSynced, nsInformer.Informer().HasSynced) {
+		klog.V(2).InfoS("ElasticQuota checker informers did not sync before context was done")
+	}
+
+	return c
+}
+
+// newElasticQuotaListWatch returns a ListWatch over ElasticQuotas in all
+// namespaces, suitable for building a SharedIndexInformer without generated
+// listers, mirroring pkg/controller/elasticquota.NewElasticQuotaListWatch.
+func newElasticQuotaListWatch(eqClient clientset.Interface) *cache.ListWatch {
+	return &cache.ListWatch{
+		ListFunc: func(opts metav1.ListOptions) (runtime.Object, error) {
+			return eqClient.SchedulingV1alpha1().ElasticQuotas(metav1.NamespaceAll).List(context.Background(), opts)
+		},
+		WatchFunc: func(opts metav1.ListOptions) (watch.Interface, error) {
+			return eqClient.SchedulingV1alpha1().ElasticQuotas(metav1.NamespaceAll).Watch(context.Background(), opts)
+		},
+	}
+}
+
+// Resolve returns the ElasticQuota governing namespace: the one that lives
+// in namespace itself, or failing that the one whose Spec.Namespaces
+// selector matches namespace's labels. It returns a nil quota, not an
+// error, when no ElasticQuota governs namespace. It reads entirely from the
+// Checker's informer caches, never the apiserver.
+func (c *Checker) Resolve(ctx context.Context, namespace string) (*schedulingv1alpha1.ElasticQuota, error) {
+	if c == nil || c.quotaInformer == nil {
+		return nil, nil
+	}
+
+	quotas := c.quotaInformer.GetIndexer().List()
+
+	for _, obj := range quotas {
+		eq, ok := obj.(*schedulingv1alpha1.ElasticQuota)
+		if ok && eq.Namespace == namespace {
+			return eq, nil
+		}
+	}
+
+	ns, err := c.nsLister.Get(namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, obj := range quotas {
+		eq, ok := obj.(*schedulingv1alpha1.ElasticQuota)
+		if !ok || eq.Spec.Namespaces == nil {
+			continue
+		}
+		selector, err := metav1.LabelSelectorAsSelector(eq.Spec.Namespaces)
+		if err != nil {
+			klog.V(2).InfoS("Invalid ElasticQuota namespaces selector; ignoring", "elasticQuota", klog.KObj(eq), "err", err)
+			continue
+		}
+		if selector.Matches(labels.Set(ns.Labels)) {
+			return eq, nil
+		}
+	}
+	return nil, nil
+}
+
+// Fits reports whether namespace's quota has room for additional on top of
+// its last observed Used, without exceeding Max. A namespace with no
+// governing quota, or a quota with no Max, always fits.
+func (c *Checker) Fits(ctx context.Context, namespace string, additional v1.ResourceList) (bool, error) {
+	if c == nil || len(additional) == 0 {
+		return true, nil
+	}
+
+	eq, err := c.Resolve(ctx, namespace)
+	if err != nil {
+		return false, err
+	}
+	if eq == nil || len(eq.Spec.Max) == 0 {
+		return true, nil
+	}
+
+	combined := v1.ResourceList{}
+	util.AddInto(combined, eq.Status.Used)
+	util.AddInto(combined, additional)
+	for name, limit := range eq.Spec.Max {
+		if want, ok := combined[name]; ok && want.Cmp(limit) > 0 {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// BelowMin reports whether namespace's quota has not yet reached its
+// declared Min. A namespace with no governing quota, or a quota with no
+// Min, is never considered below Min.
+func (c *Checker) BelowMin(ctx context.Context, namespace string) (bool, error) {
+	if c == nil {
+		return false, nil
+	}
+
+	eq, err := c.Resolve(ctx, namespace)
+	if err != nil {
+		return false, err
+	}
+	if eq == nil || len(eq.Spec.Min) == 0 {
+		return false, nil
+	}
+	return !util.Fits(eq.Spec.Min, eq.Status.Used), nil
+}
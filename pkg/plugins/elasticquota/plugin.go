@@ -0,0 +1,216 @@
+package elasticquota
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+	"k8s.io/kubernetes/pkg/scheduler/framework"
+
+	clientset "k8s-scheduler/pkg/client/clientset/versioned"
+	"k8s-scheduler/pkg/util"
+)
+
+// Name is the plugin name used in the scheduler registry and configurations.
+const Name = "ElasticQuota"
+
+// preFilterStateKey is the CycleState key PreFilter writes its result under
+// so Filter can reuse it without resolving the quota again per node.
+const preFilterStateKey framework.StateKey = "ElasticQuota"
+
+// ensure the plugin implements the required interfaces.
+var _ framework.PreFilterPlugin = &Plugin{}
+var _ framework.FilterPlugin = &Plugin{}
+var _ framework.PostFilterPlugin = &Plugin{}
+
+// New returns a new instance of the plugin.
+func New(ctx context.Context, handle framework.Handle, _ framework.PluginConfig) (framework.Plugin, error) {
+	eqClient, err := clientset.NewForConfig(handle.KubeConfig())
+	if err != nil {
+		return nil, fmt.Errorf("building ElasticQuota client: %w", err)
+	}
+
+	kubeClient, err := kubernetes.NewForConfig(handle.KubeConfig())
+	if err != nil {
+		return nil, fmt.Errorf("building kube client: %w", err)
+	}
+
+	return &Plugin{
+		handle:     handle,
+		kubeClient: kubeClient,
+		checker:    NewChecker(ctx, eqClient, kubeClient),
+	}, nil
+}
+
+// Plugin gates pods out of a node when their namespace's ElasticQuota
+// would exceed its Max, and reclaims capacity from above-Min namespaces on
+// behalf of a below-Min namespace's pod that otherwise has nowhere to go.
+type Plugin struct {
+	handle     framework.Handle
+	kubeClient kubernetes.Interface
+	checker    *Checker
+}
+
+// Name returns the plugin name.
+func (p *Plugin) Name() string { return Name }
+
+// preFilterState caches the PreFilter outcome for reuse across Filter's
+// per-node calls, since whether a namespace fits its quota does not depend
+// on the candidate node.
+type preFilterState struct {
+	status *framework.Status
+}
+
+// Clone returns the receiver unchanged: preFilterState is never mutated
+// after PreFilter writes it.
+func (s *preFilterState) Clone() framework.StateData { return s }
+
+// PreFilter rejects pod when admitting it would push its namespace's
+// ElasticQuota usage over Max.
+func (p *Plugin) PreFilter(ctx context.Context, state *framework.CycleState, pod *v1.Pod) (*framework.PreFilterResult, *framework.Status) {
+	fits, err := p.checker.Fits(ctx, pod.Namespace, util.SumPodRequests(pod))
+	status := framework.NewStatus(framework.Success, "")
+	if err != nil {
+		klog.V(2).InfoS("Failed to resolve ElasticQuota; admitting pod", "pod", klog.KObj(pod), "err", err)
+	} else if !fits {
+		status = framework.NewStatus(framework.Unschedulable, fmt.Sprintf("namespace %s would exceed its ElasticQuota Max", pod.Namespace))
+	}
+
+	state.Write(preFilterStateKey, &preFilterState{status: status})
+	return nil, status
+}
+
+// PreFilterExtensions returns nil as this plugin does not maintain
+// incremental state across AddPod/RemovePod.
+func (p *Plugin) PreFilterExtensions() framework.PreFilterExtensions { return nil }
+
+// Filter returns the quota verdict PreFilter already computed, since it does
+// not vary by node.
+func (p *Plugin) Filter(ctx context.Context, state *framework.CycleState, pod *v1.Pod, nodeInfo *framework.NodeInfo) *framework.Status {
+	st, err := getPreFilterState(state)
+	if err != nil {
+		klog.V(2).InfoS("Failed to read ElasticQuota PreFilter state; admitting pod", "pod", klog.KObj(pod), "err", err)
+		return framework.NewStatus(framework.Success, "")
+	}
+	return st.status
+}
+
+func getPreFilterState(state *framework.CycleState) (*preFilterState, error) {
+	c, err := state.Read(preFilterStateKey)
+	if err != nil {
+		return nil, err
+	}
+	s, ok := c.(*preFilterState)
+	if !ok {
+		return nil, fmt.Errorf("%+v convert to elasticquota.preFilterState error", c)
+	}
+	return s, nil
+}
+
+// PostFilter implements the reclaim path: a pod in a namespace below its
+// ElasticQuota Min may preempt the lowest-priority pods belonging to
+// namespaces that have already reached their own Min, freeing enough room
+// on some node to fit. It is a no-op for pods whose namespace is not below
+// Min, since that capacity was never guaranteed to them.
+func (p *Plugin) PostFilter(ctx context.Context, state *framework.CycleState, pod *v1.Pod, _ framework.NodeToStatusMap) (*framework.PostFilterResult, *framework.Status) {
+	belowMin, err := p.checker.BelowMin(ctx, pod.Namespace)
+	if err != nil {
+		klog.V(2).InfoS("Failed to resolve ElasticQuota for reclaim", "pod", klog.KObj(pod), "err", err)
+	}
+	if !belowMin {
+		return nil, framework.NewStatus(framework.Unschedulable, "namespace is not below its ElasticQuota Min; nothing to reclaim")
+	}
+
+	snapshot := p.handle.SnapshotSharedLister()
+	if snapshot == nil {
+		return nil, framework.NewStatus(framework.Unschedulable, "no node snapshot available for reclaim")
+	}
+	nodeInfos, err := snapshot.NodeInfos().List()
+	if err != nil {
+		return nil, framework.NewStatus(framework.Error, err.Error())
+	}
+
+	required := util.SumPodRequests(pod)
+	preemptorPriority := podPriority(pod)
+
+	for _, nodeInfo := range nodeInfos {
+		node := nodeInfo.Node()
+		if node == nil {
+			continue
+		}
+
+		victims, ok := p.selectVictims(ctx, nodeInfo, required, preemptorPriority)
+		if !ok {
+			continue
+		}
+
+		for _, victim := range victims {
+			klog.InfoS("Reclaiming pod for below-Min ElasticQuota namespace", "pod", klog.KObj(pod), "victim", klog.KObj(victim), "node", node.Name)
+			if err := p.kubeClient.CoreV1().Pods(victim.Namespace).Delete(ctx, victim.Name, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+				klog.ErrorS(err, "Failed to delete ElasticQuota reclaim victim", "victim", klog.KObj(victim))
+			}
+		}
+		return framework.NewPostFilterResultWithNominatedNode(node.Name), framework.NewStatus(framework.Success, fmt.Sprintf("reclaimed %d pod(s) on node %s", len(victims), node.Name))
+	}
+
+	return nil, framework.NewStatus(framework.Unschedulable, "no node found with reclaimable above-Min capacity")
+}
+
+// selectVictims picks the minimal, lowest-priority-first set of pods on
+// nodeInfo belonging to namespaces that are at or above their ElasticQuota
+// Min, whose removal would free at least required. Pods at or above
+// preemptorPriority are never candidates, preserving the invariant that
+// preemption never evicts a pod of equal or higher priority than the
+// preemptor. ok is false if no such set exists on this node.
+func (p *Plugin) selectVictims(ctx context.Context, nodeInfo *framework.NodeInfo, required v1.ResourceList, preemptorPriority int32) (victims []*v1.Pod, ok bool) {
+	var candidates []*v1.Pod
+	for _, podInfo := range nodeInfo.Pods {
+		if podInfo.Pod == nil {
+			continue
+		}
+		if podPriority(podInfo.Pod) >= preemptorPriority {
+			continue
+		}
+		belowMin, err := p.checker.BelowMin(ctx, podInfo.Pod.Namespace)
+		if err != nil || belowMin {
+			continue
+		}
+		candidates = append(candidates, podInfo.Pod)
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		return podPriority(candidates[i]) < podPriority(candidates[j])
+	})
+
+	freed := v1.ResourceList{}
+	for _, pod := range candidates {
+		if util.Fits(required, freed) {
+			break
+		}
+		util.AddInto(freed, util.SumPodRequests(pod))
+		victims = append(victims, pod)
+	}
+
+	return victims, util.Fits(required, freed)
+}
+
+// podPriority returns pod's priority, defaulting to 0 for pods with no
+// PriorityClass.
+func podPriority(pod *v1.Pod) int32 {
+	if pod.Spec.Priority != nil {
+		return *pod.Spec.Priority
+	}
+	return 0
+}
+
+// BuildConfig constructs the plugin config for use with the scheduler plugin registry.
+func BuildConfig() framework.PluginFactory {
+	return func(ctx context.Context, handle framework.Handle, cfg framework.PluginConfig) (framework.Plugin, error) {
+		return New(ctx, handle, cfg)
+	}
+}
@@ -0,0 +1,88 @@
+package elasticquota
+
+import (
+	"context"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/kubernetes/pkg/scheduler/framework"
+)
+
+// newTestPod builds a minimal pod with the given priority and CPU request,
+// suitable for feeding into a framework.NodeInfo.
+func newTestPod(name string, priority int32, cpu string) *v1.Pod {
+	return &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      name,
+			UID:       types.UID(name),
+		},
+		Spec: v1.PodSpec{
+			Priority: &priority,
+			Containers: []v1.Container{
+				{
+					Resources: v1.ResourceRequirements{
+						Requests: v1.ResourceList{
+							v1.ResourceCPU: resource.MustParse(cpu),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// TestSelectVictimsNeverPreemptsEqualOrHigherPriority verifies selectVictims
+// excludes candidates at or above the preemptor's priority, even when
+// removing them would otherwise free enough room.
+func TestSelectVictimsNeverPreemptsEqualOrHigherPriority(t *testing.T) {
+	checker := NewChecker(context.Background(), nil, nil)
+	p := &Plugin{checker: checker}
+
+	lower := newTestPod("lower", 0, "1")
+	equal := newTestPod("equal", 10, "1")
+	higher := newTestPod("higher", 20, "1")
+
+	nodeInfo := framework.NewNodeInfo(lower, equal, higher)
+	nodeInfo.SetNode(&v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-1"}})
+
+	required := v1.ResourceList{v1.ResourceCPU: resource.MustParse("2")}
+
+	victims, ok := p.selectVictims(context.Background(), nodeInfo, required, 10)
+
+	if ok {
+		t.Fatalf("expected selectVictims to fail to free %v without preempting equal/higher priority pods, got victims %v", required, victims)
+	}
+	for _, victim := range victims {
+		if victim.Name == "equal" || victim.Name == "higher" {
+			t.Errorf("selectVictims chose %s as a victim, which is at or above the preemptor's priority", victim.Name)
+		}
+	}
+}
+
+// TestSelectVictimsPrefersLowerPriority verifies selectVictims takes the
+// lowest-priority candidate first and stops once required is freed.
+func TestSelectVictimsPrefersLowerPriority(t *testing.T) {
+	checker := NewChecker(context.Background(), nil, nil)
+	p := &Plugin{checker: checker}
+
+	lowest := newTestPod("lowest", 0, "1")
+	low := newTestPod("low", 5, "1")
+
+	nodeInfo := framework.NewNodeInfo(lowest, low)
+	nodeInfo.SetNode(&v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-1"}})
+
+	required := v1.ResourceList{v1.ResourceCPU: resource.MustParse("1")}
+
+	victims, ok := p.selectVictims(context.Background(), nodeInfo, required, 10)
+
+	if !ok {
+		t.Fatalf("expected selectVictims to free %v, got ok=false", required)
+	}
+	if len(victims) != 1 || victims[0].Name != "lowest" {
+		t.Errorf("expected selectVictims to pick only the lowest-priority pod, got %v", victims)
+	}
+}
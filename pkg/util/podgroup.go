@@ -0,0 +1,32 @@
+package util
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	schedulingv1alpha1 "k8s-scheduler/pkg/apis/scheduling/v1alpha1"
+	clientset "k8s-scheduler/pkg/client/clientset/versioned"
+)
+
+// TransitionPodGroupPhase moves the named PodGroup to phase if it is not
+// already there. A nil pgClient is a no-op, so callers can use it
+// unconditionally even when no PodGroup client was configured.
+func TransitionPodGroupPhase(ctx context.Context, pgClient clientset.Interface, namespace, name string, phase schedulingv1alpha1.PodGroupPhase) error {
+	if pgClient == nil || name == "" {
+		return nil
+	}
+
+	pg, err := pgClient.SchedulingV1alpha1().PodGroups(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	if pg.Status.Phase == phase {
+		return nil
+	}
+
+	updated := pg.DeepCopy()
+	updated.Status.Phase = phase
+	_, err = pgClient.SchedulingV1alpha1().PodGroups(namespace).UpdateStatus(ctx, updated, metav1.UpdateOptions{})
+	return err
+}
@@ -0,0 +1,89 @@
+// Package util holds small helpers shared by the batch scheduling plugins.
+package util
+
+import (
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/kubernetes/pkg/scheduler/framework"
+)
+
+// SumNodeFree sums, across nodeInfos, each node's allocatable resources
+// minus what nodeInfo.Requested already accounts for (pods bound or assumed
+// on it), i.e. the free capacity actually available to a new gang. A node
+// already oversubscribed on a given resource contributes zero for it
+// rather than going negative.
+func SumNodeFree(nodeInfos []*framework.NodeInfo) v1.ResourceList {
+	total := v1.ResourceList{}
+	for _, nodeInfo := range nodeInfos {
+		node := nodeInfo.Node()
+		if node == nil {
+			continue
+		}
+		for name, qty := range node.Status.Allocatable {
+			free := qty.DeepCopy()
+			free.Sub(requestedQuantity(nodeInfo.Requested, name))
+			if free.Sign() < 0 {
+				free = resource.Quantity{}
+			}
+			sum := total[name].DeepCopy()
+			sum.Add(free)
+			total[name] = sum
+		}
+	}
+	return total
+}
+
+// requestedQuantity returns requested's value for name as a resource.Quantity,
+// mirroring the fields framework.Resource.Add populates from a v1.ResourceList.
+func requestedQuantity(requested *framework.Resource, name v1.ResourceName) resource.Quantity {
+	if requested == nil {
+		return resource.Quantity{}
+	}
+	switch name {
+	case v1.ResourceCPU:
+		return *resource.NewMilliQuantity(requested.MilliCPU, resource.DecimalSI)
+	case v1.ResourceMemory:
+		return *resource.NewQuantity(requested.Memory, resource.BinarySI)
+	case v1.ResourceEphemeralStorage:
+		return *resource.NewQuantity(requested.EphemeralStorage, resource.BinarySI)
+	case v1.ResourcePods:
+		return *resource.NewQuantity(int64(requested.AllowedPodNumber), resource.DecimalSI)
+	default:
+		return *resource.NewQuantity(requested.ScalarResources[name], resource.DecimalSI)
+	}
+}
+
+// SumPodRequests sums the resource requests of pod's containers. Init
+// containers are not accounted for since they do not run concurrently with
+// the main containers.
+func SumPodRequests(pod *v1.Pod) v1.ResourceList {
+	total := v1.ResourceList{}
+	for _, c := range pod.Spec.Containers {
+		for name, qty := range c.Resources.Requests {
+			sum := total[name].DeepCopy()
+			sum.Add(qty)
+			total[name] = sum
+		}
+	}
+	return total
+}
+
+// AddInto accumulates every quantity in delta onto total.
+func AddInto(total v1.ResourceList, delta v1.ResourceList) {
+	for name, qty := range delta {
+		sum := total[name].DeepCopy()
+		sum.Add(qty)
+		total[name] = sum
+	}
+}
+
+// Fits reports whether total satisfies every quantity in required.
+func Fits(required, total v1.ResourceList) bool {
+	for name, need := range required {
+		have, ok := total[name]
+		if !ok || have.Cmp(need) < 0 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,95 @@
+package util
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/kubernetes/pkg/scheduler/framework"
+)
+
+// TestSumNodeFreeSubtractsRequested verifies SumNodeFree reports allocatable
+// minus already-requested resources, not raw allocatable.
+func TestSumNodeFreeSubtractsRequested(t *testing.T) {
+	node := &v1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "node-1"},
+		Status: v1.NodeStatus{
+			Allocatable: v1.ResourceList{
+				v1.ResourceCPU:    resource.MustParse("4"),
+				v1.ResourceMemory: resource.MustParse("8Gi"),
+			},
+		},
+	}
+
+	busyPod := &v1.Pod{
+		Spec: v1.PodSpec{
+			Containers: []v1.Container{
+				{
+					Resources: v1.ResourceRequirements{
+						Requests: v1.ResourceList{
+							v1.ResourceCPU:    resource.MustParse("3"),
+							v1.ResourceMemory: resource.MustParse("2Gi"),
+						},
+					},
+				},
+			},
+		},
+	}
+
+	nodeInfo := framework.NewNodeInfo(busyPod)
+	nodeInfo.SetNode(node)
+
+	free := SumNodeFree([]*framework.NodeInfo{nodeInfo})
+
+	wantCPU := resource.MustParse("1")
+	if gotCPU := free[v1.ResourceCPU]; gotCPU.Cmp(wantCPU) != 0 {
+		t.Errorf("free CPU = %v, want %v", gotCPU.String(), wantCPU.String())
+	}
+
+	wantMemory := resource.MustParse("6Gi")
+	if gotMemory := free[v1.ResourceMemory]; gotMemory.Cmp(wantMemory) != 0 {
+		t.Errorf("free memory = %v, want %v", gotMemory.String(), wantMemory.String())
+	}
+}
+
+// TestSumNodeFreeClampsAtZero verifies an oversubscribed node contributes
+// zero for a resource rather than going negative and masking other nodes'
+// free capacity.
+func TestSumNodeFreeClampsAtZero(t *testing.T) {
+	node := &v1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "node-1"},
+		Status: v1.NodeStatus{
+			Allocatable: v1.ResourceList{
+				v1.ResourceCPU: resource.MustParse("2"),
+			},
+		},
+	}
+
+	overcommittedPod := &v1.Pod{
+		Spec: v1.PodSpec{
+			Containers: []v1.Container{
+				{
+					Resources: v1.ResourceRequirements{
+						Requests: v1.ResourceList{
+							v1.ResourceCPU: resource.MustParse("3"),
+						},
+					},
+				},
+			},
+		},
+	}
+
+	nodeInfo := framework.NewNodeInfo(overcommittedPod)
+	nodeInfo.SetNode(node)
+
+	free := SumNodeFree([]*framework.NodeInfo{nodeInfo})
+
+	gotCPU := free[v1.ResourceCPU]
+	if gotCPU.Sign() < 0 {
+		t.Errorf("free CPU = %v, want a non-negative quantity", gotCPU.String())
+	}
+	if gotCPU.Cmp(resource.MustParse("0")) != 0 {
+		t.Errorf("free CPU = %v, want 0", gotCPU.String())
+	}
+}